@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/m2arm/cli/internal/migrate"
+	"github.com/m2arm/cli/internal/planner"
+	"github.com/m2arm/cli/internal/prompt"
+	"github.com/m2arm/cli/internal/report"
+	"github.com/m2arm/cli/internal/scanner"
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// Config holds the settings the root command's persistent flags populate,
+// so subcommands read them off the injected App instead of package-level
+// vars or viper.
+type Config struct {
+	CfgFile string
+	Verbose bool
+	Output  string
+	Quiet   bool
+}
+
+// Scanner is the subset of *scanner.Scanner's behavior commands need, so
+// tests can inject a fake instead of touching the filesystem.
+type Scanner interface {
+	ScanContext(ctx context.Context) (*sdk.ScanResults, error)
+}
+
+// Planner is the subset of *planner.Planner's behavior commands need.
+type Planner interface {
+	Plan(results *sdk.ScanResults) *sdk.MigrationPlan
+}
+
+// MigrationRunner is the subset of *migrate.Runner's behavior commands
+// need.
+type MigrationRunner interface {
+	Run(ctx context.Context, plan *sdk.MigrationPlan) (migrate.Summary, error)
+}
+
+// App is the dependency container Execute builds once and wires into
+// every subcommand, so RunE functions talk to interfaces and injected
+// writers instead of package-level vars, viper, and os.Stdout.
+type App struct {
+	Config *Config
+
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Reporter is rebuilt from Config by the root command's
+	// PersistentPreRunE once flags are parsed, so it reflects whatever
+	// --output/--quiet a run was invoked with.
+	Reporter report.Reporter
+
+	// Prompter drives the "wizard" command's interactive questions.
+	Prompter prompt.Prompter
+
+	NewScanner  func(projectPath string, config map[string]interface{}, opts ...scanner.Option) (Scanner, error)
+	NewPlanner  func(targets []sdk.ARMTarget) Planner
+	NewMigrator func(projectPath string, target sdk.ARMTarget, dryRun bool) MigrationRunner
+}
+
+// NewApp returns the App wired to the real scanner/planner/migrate
+// packages and the process's stdout/stderr.
+func NewApp() *App {
+	return &App{
+		Config:   &Config{},
+		Stdout:   os.Stdout,
+		Stderr:   os.Stderr,
+		Reporter: report.NewTextReporter(os.Stdout),
+		Prompter: prompt.NewSurveyPrompter(),
+		NewScanner: func(projectPath string, config map[string]interface{}, opts ...scanner.Option) (Scanner, error) {
+			return scanner.New(projectPath, config, opts...)
+		},
+		NewPlanner: func(targets []sdk.ARMTarget) Planner {
+			return planner.New(targets)
+		},
+		NewMigrator: func(projectPath string, target sdk.ARMTarget, dryRun bool) MigrationRunner {
+			return migrate.NewRunner(projectPath, target, dryRun,
+				migrate.NewIntrinsicRewriter(),
+				migrate.NewIfdefWidener(),
+				migrate.NewCMakeFlagUpdater(),
+			)
+		},
+	}
+}
@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/m2arm/cli/internal/migrate"
+	"github.com/m2arm/cli/internal/report"
+	"github.com/m2arm/cli/internal/scanner"
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// fakeScanner is a Scanner that returns a canned result instead of
+// touching the filesystem.
+type fakeScanner struct {
+	results *sdk.ScanResults
+	err     error
+}
+
+func (f *fakeScanner) ScanContext(ctx context.Context) (*sdk.ScanResults, error) {
+	return f.results, f.err
+}
+
+// fakePlanner is a Planner that returns a canned plan regardless of input.
+type fakePlanner struct {
+	plan *sdk.MigrationPlan
+}
+
+func (f *fakePlanner) Plan(results *sdk.ScanResults) *sdk.MigrationPlan {
+	return f.plan
+}
+
+// fakeMigrationRunner is a MigrationRunner that returns a canned summary
+// instead of touching the filesystem.
+type fakeMigrationRunner struct {
+	summary migrate.Summary
+	err     error
+}
+
+func (f *fakeMigrationRunner) Run(ctx context.Context, plan *sdk.MigrationPlan) (migrate.Summary, error) {
+	return f.summary, f.err
+}
+
+// fakePrompter answers every wizard question from a canned script instead
+// of a real terminal. Confirm answers are consumed in call order.
+type fakePrompter struct {
+	input       string
+	multiSelect []string
+	confirms    []bool
+	next        int
+}
+
+func (f *fakePrompter) Input(message, def string) (string, error) { return f.input, nil }
+
+func (f *fakePrompter) MultiSelect(message string, options []string) ([]string, error) {
+	return f.multiSelect, nil
+}
+
+func (f *fakePrompter) Confirm(message string, def bool) (bool, error) {
+	answer := f.confirms[f.next]
+	f.next++
+	return answer, nil
+}
+
+// testApp returns an App wired to fakes and buffers, so a command's
+// output can be asserted on without touching the filesystem or network.
+func testApp(results *sdk.ScanResults, plan *sdk.MigrationPlan, summary migrate.Summary) (*App, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+
+	app := &App{
+		Config:   &Config{Output: "text"},
+		Stdout:   &stdout,
+		Stderr:   &stderr,
+		Reporter: report.NewTextReporter(&stdout),
+		NewScanner: func(projectPath string, config map[string]interface{}, opts ...scanner.Option) (Scanner, error) {
+			return &fakeScanner{results: results}, nil
+		},
+		NewPlanner: func(targets []sdk.ARMTarget) Planner {
+			return &fakePlanner{plan: plan}
+		},
+		NewMigrator: func(projectPath string, target sdk.ARMTarget, dryRun bool) MigrationRunner {
+			return &fakeMigrationRunner{summary: summary}
+		},
+	}
+	return app, &stdout, &stderr
+}
+
+func execute(t *testing.T, cmd *cobra.Command, args []string) {
+	t.Helper()
+	cmd.SetArgs(args)
+	cmd.SetContext(context.Background())
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute(%v) error = %v", args, err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		results *sdk.ScanResults
+		wants   []string
+	}{
+		{
+			name: "table output reports issues",
+			args: []string{"."},
+			results: &sdk.ScanResults{
+				TotalFiles:   5,
+				ScannedFiles: 5,
+				Issues: []sdk.Issue{
+					{File: "main.c", Line: 10, Category: "simd", Severity: "high", MatchedText: "_mm_add_epi32"},
+				},
+			},
+			wants: []string{"M2ARM Code Scan Results", "Found 1 compatibility issues", "main.c"},
+		},
+		{
+			name:    "no issues reports success",
+			args:    []string{"."},
+			results: &sdk.ScanResults{TotalFiles: 2, ScannedFiles: 2},
+			wants:   []string{"No obvious compatibility issues found!"},
+		},
+		{
+			name: "summary format",
+			args: []string{".", "--format", "summary"},
+			results: &sdk.ScanResults{
+				TotalFiles:   3,
+				ScannedFiles: 3,
+				Issues:       []sdk.Issue{{Category: "simd"}},
+			},
+			wants: []string{"Scan Summary", "Issues found: 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, stdout, _ := testApp(tt.results, nil, migrate.Summary{})
+			execute(t, newScanCmd(app), tt.args)
+
+			out := stdout.String()
+			for _, want := range tt.wants {
+				if !bytes.Contains([]byte(out), []byte(want)) {
+					t.Errorf("output missing %q, got:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	plan := &sdk.MigrationPlan{
+		TargetArchitecture: "arm64",
+		EstimatedEffort:    "low",
+		TotalIssues:        1,
+		Steps: []sdk.MigrationStep{
+			{ID: 1, File: "main.c", IssuesCount: 1, Changes: []sdk.CodeChange{
+				{Line: 10, Category: "simd", Original: "_mm_add_epi32", Replacement: "vaddq_s32", Confidence: "high"},
+			}},
+		},
+		Variants: []sdk.VariantPlan{
+			{Target: sdk.ARMTarget{OS: "linux", Arch: "arm64", Variant: "8"}, MArch: "armv8-a", CMakeSystemProcessor: "aarch64"},
+		},
+	}
+
+	app, stdout, _ := testApp(&sdk.ScanResults{}, plan, migrate.Summary{})
+	execute(t, newPlanCmd(app), []string{"."})
+
+	out := stdout.String()
+	for _, want := range []string{"M2ARM Migration Plan", "Step 1: main.c", "vaddq_s32", "Build Matrix"} {
+		if !bytes.Contains([]byte(out), []byte(want)) {
+			t.Errorf("output missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMigrate_DryRun(t *testing.T) {
+	plan := &sdk.MigrationPlan{
+		Variants: []sdk.VariantPlan{
+			{Target: sdk.ARMTarget{OS: "linux", Arch: "arm64", Variant: "8"}},
+		},
+	}
+	summary := migrate.Summary{
+		Changed:   1,
+		Unchanged: 1,
+		Results: []migrate.Result{
+			{Path: "main.c", Changed: true},
+			{Path: "util.c", Changed: false},
+		},
+	}
+
+	app, stdout, _ := testApp(&sdk.ScanResults{}, plan, summary)
+	execute(t, newMigrateCmd(app), []string{".", "--dry-run"})
+
+	out := stdout.String()
+	for _, want := range []string{"M2ARM Migration Simulation", "Dry Run", "main.c: would change", "util.c: unchanged"} {
+		if !bytes.Contains([]byte(out), []byte(want)) {
+			t.Errorf("output missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMigrate_DryRunIsDefault(t *testing.T) {
+	plan := &sdk.MigrationPlan{
+		Variants: []sdk.VariantPlan{
+			{Target: sdk.ARMTarget{OS: "linux", Arch: "arm64", Variant: "8"}},
+		},
+	}
+
+	app, stdout, _ := testApp(&sdk.ScanResults{}, plan, migrate.Summary{})
+	execute(t, newMigrateCmd(app), []string{"."})
+
+	if !bytes.Contains(stdout.Bytes(), []byte("Dry Run")) {
+		t.Errorf("expected dry-run mode with neither --apply nor --dry-run, got:\n%s", stdout.String())
+	}
+}
+
+func TestWizard_AppliesSelectedConfidence(t *testing.T) {
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, "main.c"), []byte("_mm_add_epi32(a, b);\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []sdk.MigrationStep{
+		{ID: 1, File: "main.c", Changes: []sdk.CodeChange{
+			{Line: 10, Original: "_mm_add_epi32", Replacement: "vaddq_s32", Confidence: "high"},
+		}},
+	}
+	plan := &sdk.MigrationPlan{
+		Steps: steps,
+		Variants: []sdk.VariantPlan{
+			{
+				Target:          sdk.ARMTarget{OS: "linux", Arch: "arm64", Variant: "8"},
+				ApplicableSteps: steps,
+			},
+		},
+	}
+	summary := migrate.Summary{
+		Changed: 1,
+		Results: []migrate.Result{{Path: "main.c", Changed: true}},
+	}
+
+	app, stdout, _ := testApp(&sdk.ScanResults{}, plan, summary)
+	app.Prompter = &fakePrompter{
+		input:       projectDir,
+		multiSelect: []string{"arm64"},
+		confirms:    []bool{true, true, true}, // auto-apply high confidence, create backup, apply now
+	}
+
+	execute(t, newWizardCmd(app), nil)
+
+	out := stdout.String()
+	for _, want := range []string{"Migration Plan", "High confidence (1 changes)", "M2ARM Migration Execution", "main.c: changed", "Migration journal:"} {
+		if !bytes.Contains([]byte(out), []byte(want)) {
+			t.Errorf("output missing %q, got:\n%s", want, out)
+		}
+	}
+
+	journalPath, err := latestJournal(projectDir)
+	if err != nil {
+		t.Fatalf("latestJournal: %v", err)
+	}
+	if _, err := os.Stat(journalPath); err != nil {
+		t.Errorf("journal file not written: %v", err)
+	}
+}
+
+func TestWizard_SkipsMigrationWhenNotConfirmed(t *testing.T) {
+	plan := &sdk.MigrationPlan{
+		Variants: []sdk.VariantPlan{
+			{Target: sdk.ARMTarget{OS: "linux", Arch: "arm64", Variant: "8"}},
+		},
+	}
+
+	app, stdout, _ := testApp(&sdk.ScanResults{}, plan, migrate.Summary{})
+	app.Prompter = &fakePrompter{
+		input:       ".",
+		multiSelect: []string{"arm64"},
+		confirms:    []bool{true, false}, // create backup? yes; apply now? no
+	}
+
+	execute(t, newWizardCmd(app), nil)
+
+	if !bytes.Contains(stdout.Bytes(), []byte("Migration skipped")) {
+		t.Errorf("expected migration to be skipped, got:\n%s", stdout.String())
+	}
+}
+
+func TestRollback_RestoresFromBackup(t *testing.T) {
+	projectDir := t.TempDir()
+	currentPath := filepath.Join(projectDir, "file.c")
+	backupPath := filepath.Join(projectDir, ".backup", "file.c")
+
+	if err := os.WriteFile(currentPath, []byte("migrated content\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(backupPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(backupPath, []byte("original content\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	currentSHA, err := sha256File(currentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j := journal{
+		Project: projectDir,
+		Entries: []journalEntry{
+			{Path: "file.c", NewSHA256: currentSHA, BackupPath: backupPath, Success: true},
+		},
+	}
+	data, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journalDirPath := filepath.Join(projectDir, journalDir)
+	if err := os.MkdirAll(journalDirPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDirPath, "20200101_000000.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app, stdout, _ := testApp(&sdk.ScanResults{}, nil, migrate.Summary{})
+	execute(t, newRollbackCmd(app), []string{projectDir})
+
+	restored, err := os.ReadFile(currentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(restored) != "original content\n" {
+		t.Errorf("expected file.c to be restored to original content, got %q", restored)
+	}
+	if !bytes.Contains(stdout.Bytes(), []byte("file.c: restored")) {
+		t.Errorf("expected restore confirmation, got:\n%s", stdout.String())
+	}
+}
+
+func TestRollback_RefusesToOverwriteModifiedFile(t *testing.T) {
+	projectDir := t.TempDir()
+	currentPath := filepath.Join(projectDir, "file.c")
+	backupPath := filepath.Join(projectDir, ".backup", "file.c")
+
+	if err := os.WriteFile(currentPath, []byte("edited after migration\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(backupPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(backupPath, []byte("original content\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	j := journal{
+		Project: projectDir,
+		Entries: []journalEntry{
+			// NewSHA256 deliberately doesn't match currentPath's actual
+			// contents, simulating an edit made after the migration ran.
+			{Path: "file.c", NewSHA256: "deadbeef", BackupPath: backupPath, Success: true},
+		},
+	}
+	data, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journalDirPath := filepath.Join(projectDir, journalDir)
+	if err := os.MkdirAll(journalDirPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDirPath, "20200101_000000.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app, stdout, _ := testApp(&sdk.ScanResults{}, nil, migrate.Summary{})
+	execute(t, newRollbackCmd(app), []string{projectDir})
+
+	current, err := os.ReadFile(currentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(current) != "edited after migration\n" {
+		t.Errorf("expected modified file to be left alone, got %q", current)
+	}
+	if !bytes.Contains(stdout.Bytes(), []byte("sha256 mismatch")) {
+		t.Errorf("expected a sha256 mismatch error, got:\n%s", stdout.String())
+	}
+}
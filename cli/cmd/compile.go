@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/m2arm/cli/internal/armtarget"
+	"github.com/m2arm/cli/internal/report"
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// compileOptions are the "compile" command's flags, bound to a local struct instead
+// of package-level vars.
+type compileOptions struct {
+	Targets string
+	Format  string
+}
+
+// newCompileCmd builds the "compile" command wired to app.
+func newCompileCmd(app *App) *cobra.Command {
+	opts := &compileOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "compile",
+		Short: "Show the cross-compilation build matrix for one or more ARM targets",
+		Long: `Compile resolves --targets into concrete ARM sub-variants and prints the
+build matrix each one compiles with: GOARM for the Go toolchain, -mfpu and
+-march for C/C++ compilers, and CMAKE_SYSTEM_PROCESSOR for CMake's
+cross-compile toolchain file. It does not invoke a toolchain itself.
+
+--targets accepts the same syntax as 'm2arm plan'/'m2arm migrate' --target:
+a comma-separated list of legacy aliases ("arm64", "armv7") or
+"[os/]arch[/variant][+fpu]" entries.
+
+Examples:
+  m2arm compile --targets arm64
+  m2arm compile --targets linux/arm/7+neon,linux/arm64/v8.2+sve
+  m2arm compile --targets armv7 --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompile(app, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Targets, "targets", "arm64", "comma-separated ARM targets, e.g. linux/arm/7+neon,linux/arm64/v8.2+sve")
+	cmd.Flags().StringVar(&opts.Format, "format", "text", "output format: text, json")
+
+	return cmd
+}
+
+// buildMatrixEntry is one ARMTarget's row in the build matrix compile
+// prints; unlike sdk.CompilationResult, it describes the flags a toolchain
+// would use rather than the outcome of actually invoking one.
+type buildMatrixEntry struct {
+	Target               string `json:"target"`
+	GOARM                string `json:"goarm,omitempty"`
+	MFPU                 string `json:"mfpu,omitempty"`
+	MArch                string `json:"march"`
+	CMakeSystemProcessor string `json:"cmake_system_processor"`
+}
+
+func runCompile(app *App, opts *compileOptions) error {
+	targets, err := armtarget.Parse(opts.Targets)
+	if err != nil {
+		return fmt.Errorf("invalid --targets: %w", err)
+	}
+
+	matrix := buildMatrix(targets)
+
+	switch opts.Format {
+	case "json":
+		encoder := json.NewEncoder(app.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(matrix)
+	default:
+		printMatrix(app.Reporter, matrix)
+		return nil
+	}
+}
+
+// buildMatrix derives the toolchain flags each target compiles with.
+func buildMatrix(targets []sdk.ARMTarget) []buildMatrixEntry {
+	matrix := make([]buildMatrixEntry, len(targets))
+	for i, t := range targets {
+		flags := armtarget.Flags(t)
+		matrix[i] = buildMatrixEntry{
+			Target:               t.String(),
+			GOARM:                flags.GOARM,
+			MFPU:                 flags.MFPU,
+			MArch:                flags.MArch,
+			CMakeSystemProcessor: flags.CMakeSystemProcessor,
+		}
+	}
+	return matrix
+}
+
+func printMatrix(rep report.Reporter, matrix []buildMatrixEntry) {
+	rep.Section("M2ARM Build Matrix")
+
+	for _, entry := range matrix {
+		rep.Step(entry.Target)
+		if entry.GOARM != "" {
+			rep.Info(fmt.Sprintf("  GOARM=%s", entry.GOARM))
+		}
+		if entry.MFPU != "" {
+			rep.Info(fmt.Sprintf("  -mfpu=%s", entry.MFPU))
+		}
+		rep.Info(fmt.Sprintf("  -march=%s", entry.MArch))
+		rep.Info(fmt.Sprintf("  CMAKE_SYSTEM_PROCESSOR=%s", entry.CMakeSystemProcessor))
+	}
+}
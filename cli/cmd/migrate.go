@@ -1,62 +1,82 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"strings"
+	"time"
 
-	"github.com/fatih/color"
-	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-)
 
-var (
-	migrateApply   bool
-	migrateDryRun  bool
-	migrateBackup  bool
+	"github.com/m2arm/cli/internal/armtarget"
+	"github.com/m2arm/cli/internal/sdk"
 )
 
-// migrateCmd represents the migrate command
-var migrateCmd = &cobra.Command{
-	Use:   "migrate [path]",
-	Short: "Execute migration plan to make code ARM-compatible",
-	Long: `Migrate executes the migration plan created by 'plan' command
-to automatically apply ARM-compatible changes to your codebase.
+// migrateOptions are the "migrate" command's flags, bound to a local struct instead
+// of package-level vars.
+type migrateOptions struct {
+	Confirm  bool
+	Apply    bool
+	DryRun   bool
+	Backup   bool
+	Target   string
+	ScanFile string
+}
+
+// newMigrateCmd builds the "migrate" command wired to app.
+func newMigrateCmd(app *App) *cobra.Command {
+	opts := &migrateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate [path]",
+		Short: "Execute migration plan to make code ARM-compatible",
+		Long: `Migrate scans the project (or loads a scan file), builds a migration
+plan from it exactly as 'm2arm plan' would, and applies that plan's
+changes to your codebase.
+
+Migrate always dry-runs unless --confirm is passed, the same convention
+OpenShift's migration tooling uses: a mutating command should require an
+explicit flag to actually write, not default to it. Passing --confirm
+also writes a JSON journal to <project>/.m2arm/migrations/, recording
+each file's before/after SHA-256, the changes applied, and its backup
+path, so 'm2arm migrate rollback' can undo it later.
 
 The migration process:
-• Creates backup of original files (unless --no-backup)
-• Applies high-confidence changes automatically
-• Reports low-confidence changes for manual review
-• Updates build system configurations
-• Suggests dependency updates
+• Creates a backup of every targeted file (unless --backup=false)
+• Rewrites x86 SIMD intrinsics to their NEON equivalent where known
+• Widens single-arch #ifdef guards to also accept the target ARM arch
+• Appends recognized ARM directives to CMakeLists.txt
+• Reports every other change as needing manual review
 
 Examples:
-  m2arm migrate --dry-run     # Simulate migration
-  m2arm migrate --apply       # Apply changes
-  m2arm migrate . --no-backup # Apply without backup`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runMigrate,
-}
+  m2arm migrate               # Simulate migration (dry-run by default)
+  m2arm migrate --confirm     # Apply changes and write a rollback journal
+  m2arm migrate rollback      # Undo the most recent --confirm run`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrate(app, opts, cmd, args)
+		},
+	}
 
-func init() {
-	rootCmd.AddCommand(migrateCmd)
+	cmd.Flags().BoolVar(&opts.Confirm, "confirm", false, "write changes and record a rollback journal (default is dry-run)")
+	cmd.Flags().BoolVar(&opts.Apply, "apply", false, "apply migration changes (default is dry-run)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "simulate migration without applying changes")
+	cmd.Flags().BoolVar(&opts.Backup, "backup", true, "create backup before applying changes")
+	cmd.Flags().StringVarP(&opts.Target, "target", "t", "arm64", "target ARM sub-variant, e.g. arm64, armv7, or linux/arm/7+neon")
+	cmd.Flags().StringVar(&opts.ScanFile, "scan-file", "", "use a scan result file (from 'm2arm scan --format json') instead of scanning the project in-process")
 
-	migrateCmd.Flags().BoolVar(&migrateApply, "apply", false, "apply migration changes (default is dry-run)")
-	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "simulate migration without applying changes")
-	migrateCmd.Flags().BoolVar(&migrateBackup, "backup", true, "create backup before applying changes")
+	cmd.MarkFlagsMutuallyExclusive("apply", "dry-run")
+	_ = cmd.Flags().MarkDeprecated("apply", "use --confirm instead")
+	_ = cmd.Flags().MarkDeprecated("dry-run", "dry-run is now the default unless --confirm is passed")
 
-	// Mark flags as mutually exclusive
-	migrateCmd.MarkFlagsMutuallyExclusive("apply", "dry-run")
+	cmd.AddCommand(newRollbackCmd(app))
 
-	// Bind flags
-	viper.BindPFlag("migrate.apply", migrateCmd.Flags().Lookup("apply"))
-	viper.BindPFlag("migrate.dry-run", migrateCmd.Flags().Lookup("dry-run"))
-	viper.BindPFlag("migrate.backup", migrateCmd.Flags().Lookup("backup"))
+	return cmd
 }
 
-func runMigrate(cmd *cobra.Command, args []string) error {
+func runMigrate(app *App, opts *migrateOptions, cmd *cobra.Command, args []string) error {
 	// Determine project path
 	projectPath := "."
 	if len(args) > 0 {
@@ -69,159 +89,229 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid path: %w", err)
 	}
 
-	// Determine if this is a dry run
-	isDryRun := migrateDryRun || !migrateApply
+	// --confirm is the only flag that actually writes changes; the
+	// deprecated --apply is honored for one release so existing scripts
+	// don't silently start dry-running.
+	isDryRun := !opts.Confirm && !opts.Apply
+
+	rep := app.Reporter
 
-	if viper.GetBool("verbose") {
-		color.Blue("🔄 Starting migration for: %s", absPath)
+	if app.Config.Verbose {
+		rep.Info(fmt.Sprintf("Starting migration for: %s", absPath))
 		if isDryRun {
-			color.Yellow("🧪 Dry run mode - no changes will be applied")
+			rep.Info("Dry run mode - no changes will be applied")
 		} else {
-			color.Green("✅ Apply mode - changes will be made")
+			rep.Info("Apply mode - changes will be made")
 		}
 	}
 
-	// Simulate migration execution
-	return executeMigration(absPath, isDryRun)
+	targets, err := armtarget.Parse(opts.Target)
+	if err != nil {
+		return fmt.Errorf("invalid --target: %w", err)
+	}
+	if len(targets) != 1 {
+		return fmt.Errorf("migrate takes exactly one --target (got %d); use 'm2arm plan' to compare multiple targets", len(targets))
+	}
+
+	results, err := loadScanResults(app, cmd, absPath, opts.ScanFile)
+	if err != nil {
+		return err
+	}
+	plan := app.NewPlanner(targets).Plan(results)
+
+	// Only apply the changes this specific target can use (e.g. skip a
+	// NEON intrinsic rewrite on a softfp build) by swapping in its
+	// VariantPlan's ApplicableSteps before executing.
+	variantPlan := *plan
+	variantPlan.Steps = plan.Variants[0].ApplicableSteps
+
+	return executeMigration(cmd.Context(), app, opts, absPath, targets[0], &variantPlan, isDryRun)
 }
 
-func executeMigration(projectPath string, dryRun bool) error {
-	// Header
+// migrateResultEntry is migrate.Result reshaped so its Err becomes a
+// string, since an error interface value carries no exported fields for
+// JSON/YAML Reporters to serialize.
+type migrateResultEntry struct {
+	Path    string `json:"path"`
+	Changed bool   `json:"changed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// migrateReport is the Result a structured Reporter receives from
+// 'm2arm migrate', combining the migration Summary with the run's mode.
+type migrateReport struct {
+	Project   string               `json:"project"`
+	DryRun    bool                 `json:"dry_run"`
+	Changed   int                  `json:"changed"`
+	Unchanged int                  `json:"unchanged"`
+	Errored   int                  `json:"errored"`
+	Results   []migrateResultEntry `json:"results"`
+}
+
+// executeMigration backs up plan's targeted files (unless dry-run or
+// --backup=false), runs plan's steps and build-system changes through
+// app's MigrationRunner, then reports a changed/unchanged/errored summary.
+func executeMigration(ctx context.Context, app *App, opts *migrateOptions, projectPath string, armTarget sdk.ARMTarget, plan *sdk.MigrationPlan, dryRun bool) error {
+	rep := app.Reporter
+
 	if dryRun {
-		color.Yellow("🧪 M2ARM Migration Simulation")
+		rep.Section("M2ARM Migration Simulation")
 	} else {
-		color.Green("🔄 M2ARM Migration Execution")
-	}
-	
-	fmt.Printf("Project: %s\n", projectPath)
-	fmt.Printf("Mode: %s\n\n", map[bool]string{true: "Dry Run", false: "Apply Changes"}[dryRun])
-
-	// Step 1: Backup creation
-	if !dryRun && migrateBackup {
-		color.Cyan("📋 Step 1: Creating backup...")
-		bar := progressbar.Default(100)
-		for i := 0; i < 100; i++ {
-			bar.Add(1)
+		rep.Section("M2ARM Migration Execution")
+	}
+
+	rep.Info(fmt.Sprintf("Project: %s", projectPath))
+	rep.Info(fmt.Sprintf("Mode: %s", map[bool]string{true: "Dry Run", false: "Apply Changes"}[dryRun]))
+
+	var backupDir string
+	if !dryRun && opts.Backup {
+		var err error
+		backupDir, err = backupTargets(projectPath, plan)
+		if err != nil {
+			return fmt.Errorf("backup failed: %w", err)
 		}
-		fmt.Printf("\n✅ Backup created at: %s_backup_20250813_120000\n\n", projectPath)
-	} else if dryRun {
-		color.Cyan("📋 Step 1: Backup creation (skipped - dry run)")
-		fmt.Println("  Would create backup at:", projectPath+"_backup_20250813_120000\n")
-	}
-
-	// Step 2: File migrations
-	color.Cyan("🔧 Step 2: Applying code changes...")
-	
-	migrations := []struct {
-		file    string
-		changes int
-		success bool
-	}{
-		{"src/math_utils.c", 2, true},
-		{"src/platform.h", 1, true},
-		{"include/simd_ops.h", 3, true},
-	}
-
-	for _, migration := range migrations {
-		if dryRun {
-			fmt.Printf("  📄 %s: would apply %d changes\n", migration.file, migration.changes)
-		} else {
-			fmt.Printf("  📄 %s: ", migration.file)
-			bar := progressbar.Default(int64(migration.changes))
-			for i := 0; i < migration.changes; i++ {
-				bar.Add(1)
-			}
-			if migration.success {
-				fmt.Printf(" ✅ %d changes applied\n", migration.changes)
-			} else {
-				fmt.Printf(" ❌ failed\n")
-			}
+		if backupDir != "" {
+			rep.Info(fmt.Sprintf("Backed up targeted files to: %s", backupDir))
 		}
+	} else if dryRun && opts.Backup {
+		rep.Info("Backup creation skipped - dry run")
 	}
-	fmt.Println()
 
-	// Step 3: Build system updates
-	color.Cyan("🏗️  Step 3: Updating build systems...")
-	
-	buildUpdates := []struct {
-		file   string
-		system string
-		count  int
-	}{
-		{"CMakeLists.txt", "cmake", 4},
-		{"Makefile", "make", 2},
+	var originalHashes map[string]string
+	if !dryRun {
+		originalHashes = hashTargets(projectPath, plan)
 	}
 
-	for _, update := range buildUpdates {
-		if dryRun {
-			fmt.Printf("  🔧 %s (%s): would update %d configurations\n", 
-				update.file, update.system, update.count)
-		} else {
-			fmt.Printf("  🔧 %s (%s): %d configurations updated ✅\n", 
-				update.file, update.system, update.count)
+	runner := app.NewMigrator(projectPath, armTarget, dryRun)
+
+	summary, err := runner.Run(ctx, plan)
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	if !dryRun {
+		journalPath, err := recordJournal(projectPath, armTarget, plan, summary, backupDir, originalHashes)
+		if err != nil {
+			rep.Warn(fmt.Sprintf("failed to write migration journal: %v", err))
+		} else if journalPath != "" {
+			rep.Info(fmt.Sprintf("Migration journal: %s", journalPath))
 		}
 	}
-	fmt.Println()
-
-	// Step 4: Dependency analysis
-	color.Cyan("📦 Step 4: Analyzing dependencies...")
-	
-	dependencies := []struct {
-		name   string
-		status string
-		action string
-	}{
-		{"numpy", "ARM compatible", "verified"},
-		{"opencv", "needs check", "manual review required"},
-		{"tensorflow", "ARM wheels available", "update recommended"},
-	}
-
-	for _, dep := range dependencies {
-		statusIcon := "✅"
-		if strings.Contains(dep.status, "needs") {
-			statusIcon = "⚠️ "
+
+	results := make([]migrateResultEntry, len(summary.Results))
+	for i, result := range summary.Results {
+		entry := migrateResultEntry{Path: result.Path, Changed: result.Changed}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
 		}
-		
-		fmt.Printf("  %s %s: %s - %s\n", statusIcon, dep.name, dep.status, dep.action)
+		results[i] = entry
 	}
-	fmt.Println()
 
-	// Results summary
-	color.Green("📊 Migration Summary")
-	if dryRun {
-		fmt.Println("  • Would apply 6 code changes across 3 files")
-		fmt.Println("  • Would update 2 build system configurations")
-		fmt.Println("  • 3 dependencies analyzed")
-		fmt.Println("  • 1 dependency requires manual review")
-	} else {
-		fmt.Println("  • Applied 6 code changes across 3 files")
-		fmt.Println("  • Updated 2 build system configurations") 
-		fmt.Println("  • 3 dependencies analyzed")
-		fmt.Println("  • 1 dependency requires manual review")
+	if rep.Structured() {
+		rep.Result(migrateReport{
+			Project:   projectPath,
+			DryRun:    dryRun,
+			Changed:   summary.Changed,
+			Unchanged: summary.Unchanged,
+			Errored:   summary.Errored,
+			Results:   results,
+		})
+		return nil
+	}
+
+	rep.Section("Results")
+	for _, result := range summary.Results {
+		switch {
+		case result.Err != nil:
+			rep.Error(fmt.Sprintf("%s: %v", result.Path, result.Err))
+		case result.Changed:
+			verb := "changed"
+			if dryRun {
+				verb = "would change"
+			}
+			rep.Info(fmt.Sprintf("%s: %s", result.Path, verb))
+		default:
+			rep.Info(fmt.Sprintf("%s: unchanged", result.Path))
+		}
 	}
 
-	fmt.Println()
+	rep.Section("Migration Summary")
+	rep.Info(fmt.Sprintf("Changed: %d", summary.Changed))
+	rep.Info(fmt.Sprintf("Unchanged: %d", summary.Unchanged))
+	rep.Info(fmt.Sprintf("Errored: %d", summary.Errored))
 
-	// Manual review items
-	if !dryRun {
-		color.Yellow("⚠️  Manual Review Required:")
-		fmt.Println("  • opencv: Check ARM wheel availability")
-		fmt.Println("  • Review generated ARM NEON code in src/math_utils.c")
-		fmt.Println("  • Test build system changes")
-		fmt.Println()
+	if len(plan.DependencyUpdates) > 0 {
+		rep.Section("Manual Review Required")
+		for _, dep := range plan.DependencyUpdates {
+			rep.Warn(fmt.Sprintf("%s (%s): %s", dep.Name, dep.Type, dep.Action))
+		}
 	}
 
-	// Next steps
-	color.Blue("🚀 Next Steps:")
+	rep.Section("Next Steps")
 	if dryRun {
-		fmt.Println("  1. Review the planned changes above")
-		fmt.Println("  2. Run 'm2arm migrate --apply' to execute migration")
+		rep.Info("1. Review the planned changes above")
+		rep.Info("2. Run 'm2arm migrate --confirm' to execute migration")
 	} else {
-		fmt.Println("  1. Review manual items listed above")
-		fmt.Println("  2. Run 'm2arm compile' to cross-compile for ARM")
-		fmt.Println("  3. Run 'm2arm test' to validate ARM builds")
-		fmt.Println("  4. Run 'm2arm optimize' for performance tuning")
+		rep.Info("1. Review manual items listed above")
+		rep.Info("2. Run 'm2arm compile' to see the cross-compilation build matrix for ARM")
+		rep.Info("3. Run 'm2arm test' to validate ARM builds")
+		rep.Info("4. Run 'm2arm optimize' for performance tuning")
+		rep.Info("5. Run 'm2arm migrate rollback' if you need to undo this")
 	}
 
 	return nil
 }
+
+// backupTargets copies every file plan.Steps/BuildSystemChanges targets
+// into a sibling "<projectPath>_backup_<timestamp>" directory, preserving
+// each file's path relative to projectPath. Returns "" if plan has no
+// targets to back up.
+func backupTargets(projectPath string, plan *sdk.MigrationPlan) (string, error) {
+	paths := make(map[string]bool)
+	for _, step := range plan.Steps {
+		paths[step.File] = true
+	}
+	for _, change := range plan.BuildSystemChanges {
+		paths[change.File] = true
+	}
+	if len(paths) == 0 {
+		return "", nil
+	}
+
+	// Nanosecond resolution, not just the second: a script running several
+	// migrations back to back (or a single process migrating more than one
+	// target) can easily land two backups in the same second, which would
+	// otherwise silently merge into one directory.
+	backupDir := fmt.Sprintf("%s_backup_%s", projectPath, time.Now().Format("20060102_150405.000000000"))
+	for relPath := range paths {
+		src := filepath.Join(projectPath, relPath)
+		dst := filepath.Join(backupDir, relPath)
+
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return "", err
+		}
+		if err := copyFile(src, dst); err != nil {
+			return "", fmt.Errorf("backing up %s: %w", relPath, err)
+		}
+	}
+
+	return backupDir, nil
+}
+
+// copyFile copies src to dst, creating dst or truncating it if it exists.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/m2arm/cli/internal/migrate"
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// journalDir is where 'm2arm migrate --confirm' records its journals and
+// 'm2arm migrate rollback' looks for them, relative to the project root.
+const journalDir = ".m2arm/migrations"
+
+// journalEntry records one file's outcome from a --confirm run: enough to
+// both audit what changed and restore it without re-deriving a plan.
+type journalEntry struct {
+	Path           string           `json:"path"`
+	OriginalSHA256 string           `json:"original_sha256,omitempty"`
+	NewSHA256      string           `json:"new_sha256,omitempty"`
+	Changes        []sdk.CodeChange `json:"changes,omitempty"`
+	BackupPath     string           `json:"backup_path,omitempty"`
+	Success        bool             `json:"success"`
+	Error          string           `json:"error,omitempty"`
+}
+
+// journal is the JSON document a --confirm run writes to journalDir and
+// rollback reads back.
+type journal struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Project   string         `json:"project"`
+	Target    sdk.ARMTarget  `json:"target"`
+	Entries   []journalEntry `json:"entries"`
+}
+
+// hashTargets returns the sha256 of every file plan.Steps/BuildSystemChanges
+// targets, read before the migration runs. A file that can't be read (e.g.
+// one a prior step is about to create) is simply omitted.
+func hashTargets(projectPath string, plan *sdk.MigrationPlan) map[string]string {
+	hashes := make(map[string]string)
+	for _, path := range targetPaths(plan) {
+		sum, err := sha256File(filepath.Join(projectPath, path))
+		if err == nil {
+			hashes[path] = sum
+		}
+	}
+	return hashes
+}
+
+// targetPaths lists every file path a MigrationPlan's Steps or
+// BuildSystemChanges target, matching the order migrate.Runner visits
+// them in.
+func targetPaths(plan *sdk.MigrationPlan) []string {
+	paths := make([]string, 0, len(plan.Steps)+len(plan.BuildSystemChanges))
+	for _, step := range plan.Steps {
+		paths = append(paths, step.File)
+	}
+	for _, change := range plan.BuildSystemChanges {
+		paths = append(paths, change.File)
+	}
+	return paths
+}
+
+// changesByFile maps each file plan.Steps targets to the CodeChanges it
+// scheduled against it, for recordJournal to attach to the matching entry.
+func changesByFile(plan *sdk.MigrationPlan) map[string][]sdk.CodeChange {
+	changes := make(map[string][]sdk.CodeChange)
+	for _, step := range plan.Steps {
+		changes[step.File] = step.Changes
+	}
+	return changes
+}
+
+// recordJournal builds a journal from summary and writes it to
+// <projectPath>/.m2arm/migrations/<timestamp>.json, returning the path
+// written. backupDir and originalHashes are what executeMigration
+// collected before running the migration; both may be empty if --backup
+// was disabled or the project had no matching files yet.
+func recordJournal(projectPath string, armTarget sdk.ARMTarget, plan *sdk.MigrationPlan, summary migrate.Summary, backupDir string, originalHashes map[string]string) (string, error) {
+	changes := changesByFile(plan)
+
+	entries := make([]journalEntry, len(summary.Results))
+	for i, result := range summary.Results {
+		entry := journalEntry{
+			Path:           result.Path,
+			OriginalSHA256: originalHashes[result.Path],
+			Changes:        changes[result.Path],
+			Success:        result.Err == nil,
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		} else if sum, err := sha256File(filepath.Join(projectPath, result.Path)); err == nil {
+			entry.NewSHA256 = sum
+		}
+		if backupDir != "" {
+			entry.BackupPath = filepath.Join(backupDir, result.Path)
+		}
+		entries[i] = entry
+	}
+
+	j := journal{
+		Timestamp: time.Now(),
+		Project:   projectPath,
+		Target:    armTarget,
+		Entries:   entries,
+	}
+
+	dir := filepath.Join(projectPath, journalDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating journal directory: %w", err)
+	}
+
+	// Nanosecond resolution, not just the second: two migrations run back
+	// to back (e.g. scripted, or multiple targets against one project)
+	// can land in the same second, which would otherwise overwrite one
+	// journal with the other instead of recording both.
+	path := filepath.Join(dir, j.Timestamp.Format("20060102_150405.000000000")+".json")
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding journal: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("writing journal: %w", err)
+	}
+	return path, nil
+}
+
+// latestJournal returns the most recently written journal under
+// <projectPath>/.m2arm/migrations, by filename (timestamps sort
+// lexically since they share recordJournal's layout).
+func latestJournal(projectPath string) (string, error) {
+	dir := filepath.Join(projectPath, journalDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("no migration journals found in %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no migration journals found in %s", dir)
+	}
+
+	sort.Strings(names)
+	return filepath.Join(dir, names[len(names)-1]), nil
+}
+
+// sha256File returns the hex-encoded sha256 of path's contents.
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// rollbackOptions are the "migrate rollback" subcommand's flags.
+type rollbackOptions struct {
+	Journal string
+}
+
+// newRollbackCmd builds the "migrate rollback" subcommand wired to app.
+func newRollbackCmd(app *App) *cobra.Command {
+	opts := &rollbackOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rollback [path]",
+		Short: "Restore files from a migration journal's backups",
+		Long: `Rollback reads a journal 'm2arm migrate --confirm' wrote and restores every
+file it touched from its recorded backup, verifying each file's current
+SHA-256 still matches what the migration left behind before overwriting
+it, so later edits aren't silently clobbered.
+
+By default rollback uses the most recently written journal under
+<path>/.m2arm/migrations; pass --journal to use a specific one.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollback(app, opts, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Journal, "journal", "", "path to a specific journal file (default: latest under <path>/.m2arm/migrations)")
+
+	return cmd
+}
+
+func runRollback(app *App, opts *rollbackOptions, args []string) error {
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+
+	rep := app.Reporter
+
+	journalPath := opts.Journal
+	if journalPath == "" {
+		journalPath, err = latestJournal(absPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(journalPath)
+	if err != nil {
+		return fmt.Errorf("reading journal: %w", err)
+	}
+	var j journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("parsing journal: %w", err)
+	}
+
+	rep.Section("M2ARM Migration Rollback")
+	rep.Info(fmt.Sprintf("Journal: %s", journalPath))
+
+	var restored, skipped, errored int
+	for _, entry := range j.Entries {
+		switch {
+		case !entry.Success || entry.BackupPath == "":
+			rep.Info(fmt.Sprintf("%s: skipped (no backup recorded)", entry.Path))
+			skipped++
+		default:
+			if err := rollbackFile(absPath, entry); err != nil {
+				rep.Error(fmt.Sprintf("%s: %v", entry.Path, err))
+				errored++
+				continue
+			}
+			rep.Info(fmt.Sprintf("%s: restored", entry.Path))
+			restored++
+		}
+	}
+
+	rep.Section("Rollback Summary")
+	rep.Info(fmt.Sprintf("Restored: %d", restored))
+	rep.Info(fmt.Sprintf("Skipped: %d", skipped))
+	rep.Info(fmt.Sprintf("Errored: %d", errored))
+
+	return nil
+}
+
+// rollbackFile restores entry.Path from entry.BackupPath, refusing to
+// overwrite it if its current sha256 no longer matches entry.NewSHA256 -
+// a sign it was edited since the migration ran.
+func rollbackFile(projectPath string, entry journalEntry) error {
+	path := filepath.Join(projectPath, entry.Path)
+
+	if entry.NewSHA256 != "" {
+		sum, err := sha256File(path)
+		if err != nil {
+			return fmt.Errorf("reading current file: %w", err)
+		}
+		if sum != entry.NewSHA256 {
+			return fmt.Errorf("modified since migration (sha256 mismatch); refusing to overwrite")
+		}
+	}
+
+	return copyFile(entry.BackupPath, path)
+}
@@ -5,25 +5,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
+	"github.com/m2arm/cli/internal/armtarget"
+	"github.com/m2arm/cli/internal/report"
 	"github.com/m2arm/cli/internal/sdk"
 )
 
-var (
-	planTarget string
-	planOutput string
-)
+// planOptions are the "plan" command's flags, bound to a local struct instead of
+// package-level vars.
+type planOptions struct {
+	Target   string
+	Format   string
+	ScanFile string
+}
+
+// newPlanCmd builds the "plan" command wired to app.
+func newPlanCmd(app *App) *cobra.Command {
+	opts := &planOptions{}
 
-// planCmd represents the plan command
-var planCmd = &cobra.Command{
-	Use:   "plan [path]",
-	Short: "Create a migration plan for ARM architecture",
-	Long: `Plan analyzes scan results and creates a detailed migration plan
+	cmd := &cobra.Command{
+		Use:   "plan [path]",
+		Short: "Create a migration plan for ARM architecture",
+		Long: `Plan analyzes scan results and creates a detailed migration plan
 for moving your application to ARM architecture.
 
 The plan includes:
@@ -32,28 +37,32 @@ The plan includes:
 • Dependency compatibility updates
 • Testing strategy recommendations
 • Effort estimation
+• A build matrix and variant-conditional changes for every requested target
+
+--target accepts a comma-separated list of ARM sub-variants: the legacy
+"arm64"/"armv7"/"armv6"/"armv5" aliases, or "[os/]arch[/variant][+fpu]",
+e.g. "linux/arm/7+neon" or "arm64/v8.2+sve".
 
 Examples:
   m2arm plan .
   m2arm plan --target arm64
   m2arm plan . --output json
-  m2arm plan /path/to/project --target armv7`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runPlan,
-}
-
-func init() {
-	rootCmd.AddCommand(planCmd)
+  m2arm plan /path/to/project --target armv7
+  m2arm plan /path/to/project --target linux/arm/7+neon,linux/arm64/v8.2+sve`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlan(app, opts, cmd, args)
+		},
+	}
 
-	planCmd.Flags().StringVarP(&planTarget, "target", "t", "arm64", "target ARM architecture (arm64, armv7)")
-	planCmd.Flags().StringVar(&planOutput, "format", "text", "output format: text, json")
+	cmd.Flags().StringVarP(&opts.Target, "target", "t", "arm64", "comma-separated target ARM sub-variants, e.g. arm64, armv7, or linux/arm/7+neon")
+	cmd.Flags().StringVar(&opts.Format, "format", "text", "output format: text, json")
+	cmd.Flags().StringVar(&opts.ScanFile, "scan-file", "", "use a scan result file (from 'm2arm scan --format json') instead of scanning the project in-process")
 
-	// Bind flags
-	viper.BindPFlag("plan.target", planCmd.Flags().Lookup("target"))
-	viper.BindPFlag("plan.format", planCmd.Flags().Lookup("format"))
+	return cmd
 }
 
-func runPlan(cmd *cobra.Command, args []string) error {
+func runPlan(app *App, opts *planOptions, cmd *cobra.Command, args []string) error {
 	// Determine project path
 	projectPath := "."
 	if len(args) > 0 {
@@ -66,202 +75,168 @@ func runPlan(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid path: %w", err)
 	}
 
-	if viper.GetBool("verbose") {
-		color.Blue("📋 Creating migration plan for: %s", absPath)
-		color.Blue("🎯 Target architecture: %s", planTarget)
+	rep := app.Reporter
+
+	if app.Config.Verbose {
+		rep.Info(fmt.Sprintf("Creating migration plan for: %s", absPath))
+		rep.Info(fmt.Sprintf("Target architecture: %s", opts.Target))
+	}
+
+	targets, err := armtarget.Parse(opts.Target)
+	if err != nil {
+		return fmt.Errorf("invalid --target: %w", err)
+	}
+
+	results, err := loadScanResults(app, cmd, absPath, opts.ScanFile)
+	if err != nil {
+		return err
 	}
 
-	// Create a mock migration plan (in real implementation, this would use the SDK)
-	plan := createMockMigrationPlan(planTarget)
-
-	// Output results
-	switch planOutput {
-	case "json":
-		return outputPlanJSON(plan)
-	case "text":
-		fallthrough
-	default:
-		return outputPlanText(plan)
+	plan := app.NewPlanner(targets).Plan(results)
+
+	// --format json is kept for scripts that want plan's JSON without the
+	// root --output flag; anything else defers to the ambient Reporter,
+	// same as 'm2arm scan'.
+	if cmd.Flags().Changed("format") && opts.Format == "json" {
+		return outputPlanJSON(app, plan)
 	}
+
+	if rep.Structured() {
+		rep.Result(plan)
+		return nil
+	}
+	return outputPlanText(rep, plan)
 }
 
-func createMockMigrationPlan(target string) *sdk.MigrationPlan {
-	return &sdk.MigrationPlan{
-		TargetArchitecture: target,
-		CreatedAt:         time.Now(),
-		TotalIssues:       3,
-		EstimatedEffort:   "medium",
-		Steps: []sdk.MigrationStep{
-			{
-				ID:          1,
-				Type:        "file_migration",
-				File:        "src/math_utils.c",
-				IssuesCount: 2,
-				Changes: []sdk.CodeChange{
-					{
-						Line:        42,
-						Category:    "x86_intrinsics",
-						Original:    "_mm_add_ps(a, b)",
-						Replacement: "vaddq_f32(a, b)",
-						Confidence:  "high",
-					},
-					{
-						Line:        56,
-						Category:    "x86_intrinsics",
-						Original:    "_mm_mul_ps(x, y)",
-						Replacement: "vmulq_f32(x, y)",
-						Confidence:  "high",
-					},
-				},
-			},
-			{
-				ID:          2,
-				Type:        "file_migration",
-				File:        "src/platform.h",
-				IssuesCount: 1,
-				Changes: []sdk.CodeChange{
-					{
-						Line:        15,
-						Category:    "architecture_checks",
-						Original:    "#ifdef __x86_64__",
-						Replacement: "#if defined(__x86_64__) || defined(__aarch64__)",
-						Confidence:  "high",
-					},
-				},
-			},
-		},
-		BuildSystemChanges: []sdk.BuildSystemChange{
-			{
-				File:   "CMakeLists.txt",
-				System: "cmake",
-				Changes: []string{
-					"Add ARM64 target support",
-					"Set CMAKE_SYSTEM_PROCESSOR for cross-compilation",
-					"Add ARM-specific compiler flags",
-					"Update architecture detection logic",
-				},
-			},
-		},
-		DependencyUpdates: []sdk.DependencyUpdate{
-			{
-				Name:           "numpy",
-				CurrentVersion: "1.21.0",
-				Type:           "python",
-				Action:         "verify_arm_support",
-				Notes:          []string{"Check for ARM wheel availability"},
-			},
-		},
-		TestingStrategy: sdk.TestingStrategy{
-			UnitTests: sdk.TestConfig{
-				Required:   true,
-				Platforms:  []string{target, "x86_64"},
-				FocusAreas: []string{"math operations", "memory access", "SIMD code"},
-			},
-			IntegrationTests: sdk.TestConfig{
-				Required:     true,
-				Environments: []string{"native_arm", "emulated_arm", "cross_platform"},
-			},
-			PerformanceTests: sdk.TestConfig{
-				Required:   true,
-				Metrics:    []string{"execution_time", "memory_usage", "power_consumption"},
-				Comparison: "x86_64",
-			},
-		},
+// loadScanResults returns the ScanResults a plan is built from: the
+// contents of scanFile if non-empty, otherwise the result of scanning
+// projectPath in-process with dependency resolution turned on.
+func loadScanResults(app *App, cmd *cobra.Command, projectPath, scanFile string) (*sdk.ScanResults, error) {
+	if scanFile != "" {
+		data, err := os.ReadFile(scanFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading scan file: %w", err)
+		}
+
+		var results sdk.ScanResults
+		if err := json.Unmarshal(data, &results); err != nil {
+			return nil, fmt.Errorf("parsing scan file: %w", err)
+		}
+		return &results, nil
+	}
+
+	sc, err := app.NewScanner(projectPath, map[string]interface{}{
+		"recursive": true,
+		"verbose":   app.Config.Verbose,
+		// plan's dependency compatibility updates need each dependency's
+		// resolved ARM compatibility, unlike 'm2arm scan' (which defaults
+		// this off; see --resolve-deps).
+		"resolve_deps": true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scanner: %w", err)
 	}
+
+	results, err := sc.ScanContext(cmd.Context())
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+	return results, nil
 }
 
-func outputPlanJSON(plan *sdk.MigrationPlan) error {
-	encoder := json.NewEncoder(os.Stdout)
+func outputPlanJSON(app *App, plan *sdk.MigrationPlan) error {
+	encoder := json.NewEncoder(app.Stdout)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(plan)
 }
 
-func outputPlanText(plan *sdk.MigrationPlan) error {
-	// Header
-	color.Green("📋 M2ARM Migration Plan")
-	fmt.Printf("Target: %s | Created: %s | Effort: %s\n\n",
+func outputPlanText(rep report.Reporter, plan *sdk.MigrationPlan) error {
+	rep.Section("M2ARM Migration Plan")
+	rep.Info(fmt.Sprintf("Target: %s | Created: %s | Effort: %s",
 		plan.TargetArchitecture,
 		plan.CreatedAt.Format("2006-01-02 15:04:05"),
-		plan.EstimatedEffort)
+		plan.EstimatedEffort))
 
-	// Migration Steps
 	if len(plan.Steps) > 0 {
-		color.Yellow("🔧 Migration Steps (%d issues to resolve):", plan.TotalIssues)
-		
+		rep.Section(fmt.Sprintf("Migration Steps (%d issues to resolve)", plan.TotalIssues))
+
 		for _, step := range plan.Steps {
-			fmt.Printf("\n  Step %d: %s\n", step.ID, step.File)
-			fmt.Printf("    Issues: %d\n", step.IssuesCount)
-			
+			rep.Step(fmt.Sprintf("Step %d: %s (%d issues)", step.ID, step.File, step.IssuesCount))
+
 			for _, change := range step.Changes {
-				confidenceIcon := "🟢"
-				if change.Confidence == "medium" {
-					confidenceIcon = "🟡"
-				} else if change.Confidence == "low" {
-					confidenceIcon = "🔴"
+				rep.Info(fmt.Sprintf("  Line %d (%s, %s confidence): %s", change.Line, change.Category, change.Confidence, change.Original))
+				if change.RequiresFeature != "" {
+					rep.Info(fmt.Sprintf("    requires: %s", change.RequiresFeature))
 				}
-				
-				fmt.Printf("    %s Line %d (%s): %s\n",
-					confidenceIcon, change.Line, change.Category, change.Original)
-				fmt.Printf("      → %s\n", change.Replacement)
+				rep.Info(fmt.Sprintf("    -> %s", change.Replacement))
 			}
 		}
-		fmt.Println()
 	}
 
-	// Build System Changes
 	if len(plan.BuildSystemChanges) > 0 {
-		color.Cyan("🏗️  Build System Changes:")
-		
+		rep.Section("Build System Changes")
+
 		for _, buildChange := range plan.BuildSystemChanges {
-			fmt.Printf("\n  %s (%s):\n", buildChange.File, buildChange.System)
+			rep.Step(fmt.Sprintf("%s (%s)", buildChange.File, buildChange.System))
 			for _, change := range buildChange.Changes {
-				fmt.Printf("    • %s\n", change)
+				rep.Info(change)
 			}
 		}
-		fmt.Println()
 	}
 
-	// Dependency Updates
+	if len(plan.Variants) > 0 {
+		rep.Section("Build Matrix")
+
+		for _, variant := range plan.Variants {
+			rep.Step(variant.Target.String())
+			if variant.GOARM != "" {
+				rep.Info(fmt.Sprintf("  GOARM=%s", variant.GOARM))
+			}
+			if variant.MFPU != "" {
+				rep.Info(fmt.Sprintf("  -mfpu=%s", variant.MFPU))
+			}
+			rep.Info(fmt.Sprintf("  -march=%s", variant.MArch))
+			rep.Info(fmt.Sprintf("  CMAKE_SYSTEM_PROCESSOR=%s", variant.CMakeSystemProcessor))
+			rep.Info(fmt.Sprintf("  Applicable steps: %d/%d", len(variant.ApplicableSteps), len(plan.Steps)))
+		}
+	}
+
 	if len(plan.DependencyUpdates) > 0 {
-		color.Magenta("📦 Dependency Updates:")
-		
+		rep.Section("Dependency Updates")
+
 		for _, dep := range plan.DependencyUpdates {
-			fmt.Printf("\n  %s (%s) - %s:\n", dep.Name, dep.CurrentVersion, dep.Action)
+			rep.Step(fmt.Sprintf("%s (%s) - %s", dep.Name, dep.CurrentVersion, dep.Action))
 			for _, note := range dep.Notes {
-				fmt.Printf("    • %s\n", note)
+				rep.Info(note)
 			}
 		}
-		fmt.Println()
 	}
 
-	// Testing Strategy
-	color.Blue("🧪 Testing Strategy:")
-	
+	rep.Section("Testing Strategy")
+
 	if plan.TestingStrategy.UnitTests.Required {
-		fmt.Printf("\n  Unit Tests:\n")
-		fmt.Printf("    Platforms: %v\n", plan.TestingStrategy.UnitTests.Platforms)
-		fmt.Printf("    Focus Areas: %v\n", plan.TestingStrategy.UnitTests.FocusAreas)
+		rep.Step("Unit Tests")
+		rep.Info(fmt.Sprintf("  Platforms: %v", plan.TestingStrategy.UnitTests.Platforms))
+		rep.Info(fmt.Sprintf("  Focus Areas: %v", plan.TestingStrategy.UnitTests.FocusAreas))
 	}
-	
+
 	if plan.TestingStrategy.IntegrationTests.Required {
-		fmt.Printf("\n  Integration Tests:\n")
-		fmt.Printf("    Environments: %v\n", plan.TestingStrategy.IntegrationTests.Environments)
+		rep.Step("Integration Tests")
+		rep.Info(fmt.Sprintf("  Environments: %v", plan.TestingStrategy.IntegrationTests.Environments))
 	}
-	
+
 	if plan.TestingStrategy.PerformanceTests.Required {
-		fmt.Printf("\n  Performance Tests:\n")
-		fmt.Printf("    Metrics: %v\n", plan.TestingStrategy.PerformanceTests.Metrics)
-		fmt.Printf("    Baseline: %s\n", plan.TestingStrategy.PerformanceTests.Comparison)
+		rep.Step("Performance Tests")
+		rep.Info(fmt.Sprintf("  Metrics: %v", plan.TestingStrategy.PerformanceTests.Metrics))
+		rep.Info(fmt.Sprintf("  Baseline: %s", plan.TestingStrategy.PerformanceTests.Comparison))
 	}
 
-	// Next Steps
-	fmt.Println()
-	color.Blue("🚀 Next Steps:")
-	fmt.Println("  1. Review the migration plan carefully")
-	fmt.Println("  2. Run 'm2arm migrate --dry-run' to simulate changes")
-	fmt.Println("  3. Execute migration with 'm2arm migrate --apply'")
-	fmt.Println("  4. Cross-compile with 'm2arm compile'")
-	fmt.Println("  5. Test on ARM targets with 'm2arm test'")
+	rep.Section("Next Steps")
+	rep.Info("1. Review the migration plan carefully")
+	rep.Info("2. Run 'm2arm migrate' to simulate changes (dry-run is the default)")
+	rep.Info("3. Execute migration with 'm2arm migrate --confirm'")
+	rep.Info("4. Show the cross-compilation build matrix with 'm2arm compile'")
+	rep.Info("5. Test on ARM targets with 'm2arm test'")
 
 	return nil
 }
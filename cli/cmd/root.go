@@ -6,24 +6,24 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-)
 
-var (
-	cfgFile string
-	verbose bool
-	output  string
+	"github.com/m2arm/cli/internal/report"
 )
 
-// rootCmd represents the base command when called without any subcommands
-var rootCmd = &cobra.Command{
-	Use:   "m2arm",
-	Short: "Migration and Modernization to ARM",
-	Long: `M2ARM is a comprehensive toolkit for migrating and modernizing applications
+// newRootCmd builds the "m2arm" command tree wired to app. Execute is the
+// only caller in normal operation; tests call this directly with a fake
+// App to drive a subcommand without touching the real filesystem or
+// network.
+func newRootCmd(app *App) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "m2arm",
+		Short: "Migration and Modernization to ARM",
+		Long: `M2ARM is a comprehensive toolkit for migrating and modernizing applications
 from x86 to ARM architecture while maintaining cross-platform compatibility.
 
 M2ARM helps you:
 • Scan code for x86-specific instructions and dependencies
-• Plan and execute ARM migration strategies  
+• Plan and execute ARM migration strategies
 • Cross-compile for multiple architectures
 • Test ARM builds using emulation and containers
 • Optimize performance for ARM processors
@@ -31,35 +31,50 @@ M2ARM helps you:
 Examples:
   m2arm scan /path/to/project
   m2arm plan --target arm64
-  m2arm migrate --apply
+  m2arm migrate --confirm
   m2arm compile --targets linux/arm64,windows/amd64
   m2arm test --platform arm64`,
-	Version: "0.1.0",
-}
+		Version: "0.1.0",
 
-// Execute adds all child commands to the root command and sets flags appropriately.
-func Execute() error {
-	return rootCmd.Execute()
-}
+		// PersistentPreRunE builds app.Reporter from the parsed flags every
+		// subcommand's RunE reads back off app, so --output/--quiet apply
+		// no matter which command is invoked.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if app.Config.Quiet {
+				app.Reporter = report.NewQuietReporter(app.Stdout, app.Stderr)
+			} else {
+				app.Reporter = report.New(app.Config.Output, app.Stdout, app.Stderr)
+			}
+			return nil
+		},
+	}
 
-func init() {
-	cobra.OnInitialize(initConfig)
+	root.PersistentFlags().StringVar(&app.Config.CfgFile, "config", "", "config file (default is $HOME/.m2arm.yaml)")
+	root.PersistentFlags().BoolVarP(&app.Config.Verbose, "verbose", "v", false, "verbose output")
+	root.PersistentFlags().StringVarP(&app.Config.Output, "output", "o", "text", "output format (text, json, yaml)")
+	root.PersistentFlags().BoolVarP(&app.Config.Quiet, "quiet", "q", false, "suppress progress and status output; print only the final result")
 
-	// Global flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.m2arm.yaml)")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "text", "output format (text, json, yaml)")
+	cobra.OnInitialize(func() { initConfig(app.Config) })
 
-	// Bind flags to viper
-	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	root.AddCommand(newScanCmd(app))
+	root.AddCommand(newPlanCmd(app))
+	root.AddCommand(newMigrateCmd(app))
+	root.AddCommand(newCompileCmd(app))
+	root.AddCommand(newWizardCmd(app))
+
+	return root
+}
+
+// Execute builds the real App and runs the "m2arm" command tree.
+func Execute() error {
+	return newRootCmd(NewApp()).Execute()
 }
 
 // initConfig reads in config file and ENV variables if set.
-func initConfig() {
-	if cfgFile != "" {
+func initConfig(cfg *Config) {
+	if cfg.CfgFile != "" {
 		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
+		viper.SetConfigFile(cfg.CfgFile)
 	} else {
 		// Find home directory.
 		home, err := os.UserHomeDir()
@@ -75,7 +90,7 @@ func initConfig() {
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil && verbose {
+	if err := viper.ReadInConfig(); err == nil && cfg.Verbose {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
@@ -1,68 +1,93 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
+	"strings"
 
-	"github.com/fatih/color"
-	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
+	"github.com/m2arm/cli/internal/report"
 	"github.com/m2arm/cli/internal/scanner"
 	"github.com/m2arm/cli/internal/sdk"
 )
 
-var (
-	scanPath      string
-	scanRecursive bool
-	scanOutput    string
-)
+// scanOptions are the "scan" command's flags, bound to a local struct instead of
+// package-level vars so newScanCmd can be called more than once (e.g. in
+// tests) without one invocation's flags leaking into another's.
+type scanOptions struct {
+	Recursive   bool
+	Format      string
+	Targets     string
+	Cgo         bool
+	ResolveDeps bool
+}
 
-// scanCmd represents the scan command
-var scanCmd = &cobra.Command{
-	Use:   "scan [path]",
-	Short: "Scan code for x86-specific instructions and dependencies",
-	Long: `Scan analyzes your codebase for x86-specific instructions, inline assembly,
+// newScanCmd builds the "scan" command wired to app.
+func newScanCmd(app *App) *cobra.Command {
+	opts := &scanOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "scan [path]",
+		Short: "Scan code for x86-specific instructions and dependencies",
+		Long: `Scan analyzes your codebase for x86-specific instructions, inline assembly,
 architecture-dependent code, and platform-specific dependencies that may need
 attention when migrating to ARM.
 
 The scan will identify:
-â€¢ Inline assembly code
-â€¢ x86 SIMD intrinsics (SSE, AVX, etc.)
-â€¢ Architecture-specific #ifdef blocks
-â€¢ Platform-specific API calls
-â€¢ Dependencies that may not support ARM
+• Inline assembly code
+• x86 SIMD intrinsics (SSE, AVX, etc.)
+• Architecture-specific #ifdef blocks
+• Platform-specific API calls
+• Dependencies that may not support ARM
 
 Examples:
   m2arm scan .
   m2arm scan /path/to/project --recursive
   m2arm scan . --output json
-  m2arm scan . --output table`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runScan,
-}
+  m2arm scan . --output table
+  m2arm scan . --format sarif`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScan(app, opts, cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Recursive, "recursive", "r", true, "recursively scan subdirectories")
+	cmd.Flags().StringVar(&opts.Format, "format", "table", "output format: table, json, summary, sarif")
+	cmd.Flags().StringVar(&opts.Targets, "targets", "", "comma-separated GOOS/GOARCH build contexts to evaluate guards against, e.g. linux/arm64,darwin/arm64")
+	cmd.Flags().BoolVar(&opts.Cgo, "cgo", false, "evaluate --targets with CGO_ENABLED=1")
+	cmd.Flags().BoolVar(&opts.ResolveDeps, "resolve-deps", false, "look up each dependency's ARM compatibility against its package registry (PyPI/npm/crates.io); off by default since it's a network call per dependency")
 
-func init() {
-	rootCmd.AddCommand(scanCmd)
+	return cmd
+}
 
-	scanCmd.Flags().BoolVarP(&scanRecursive, "recursive", "r", true, "recursively scan subdirectories")
-	scanCmd.Flags().StringVar(&scanOutput, "format", "table", "output format: table, json, summary")
+// parseScanTargets parses a comma-separated "GOOS/GOARCH" list (as accepted
+// by --targets) into scanner.BuildTargets, applying cgo to each.
+func parseScanTargets(csv string, cgo bool) ([]scanner.BuildTarget, error) {
+	var targets []scanner.BuildTarget
+	for _, raw := range strings.Split(csv, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
 
-	// Bind flags
-	viper.BindPFlag("scan.recursive", scanCmd.Flags().Lookup("recursive"))
-	viper.BindPFlag("scan.format", scanCmd.Flags().Lookup("format"))
+		target, err := scanner.ParseBuildTarget(raw)
+		if err != nil {
+			return nil, err
+		}
+		target.CgoEnabled = cgo
+		targets = append(targets, target)
+	}
+	return targets, nil
 }
 
-func runScan(cmd *cobra.Command, args []string) error {
+func runScan(app *App, opts *scanOptions, cmd *cobra.Command, args []string) error {
 	// Determine scan path
+	scanPath := "."
 	if len(args) > 0 {
 		scanPath = args[0]
-	} else {
-		scanPath = "."
 	}
 
 	// Convert to absolute path
@@ -76,93 +101,114 @@ func runScan(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("path does not exist: %s", absPath)
 	}
 
-	if viper.GetBool("verbose") {
-		color.Blue("ğŸ” Scanning project at: %s", absPath)
+	rep := app.Reporter
+
+	if app.Config.Verbose {
+		rep.Info(fmt.Sprintf("Scanning project at: %s", absPath))
+	}
+
+	var scannerOpts []scanner.Option
+	if opts.Targets != "" {
+		targets, err := parseScanTargets(opts.Targets, opts.Cgo)
+		if err != nil {
+			return err
+		}
+		scannerOpts = append(scannerOpts, scanner.WithTargets(targets...))
 	}
 
 	// Initialize scanner
-	scanner := scanner.New(absPath, map[string]interface{}{
-		"recursive": scanRecursive,
-		"verbose":   viper.GetBool("verbose"),
-	})
+	sc, err := app.NewScanner(absPath, map[string]interface{}{
+		"recursive":    opts.Recursive,
+		"verbose":      app.Config.Verbose,
+		"resolve_deps": opts.ResolveDeps,
+	}, scannerOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to initialize scanner: %w", err)
+	}
 
 	// Perform scan
-	results, err := scanner.Scan()
+	rep.Step(fmt.Sprintf("Scanning %s", absPath))
+	results, err := sc.ScanContext(cmd.Context())
 	if err != nil {
+		rep.Error(err.Error())
 		return fmt.Errorf("scan failed: %w", err)
 	}
 
-	// Output results
-	switch scanOutput {
-	case "json":
-		return outputJSON(results)
-	case "summary":
-		return outputSummary(results)
-	case "table":
-		fallthrough
-	default:
-		return outputTable(results)
+	// --format is for the output schemas the root --output/--quiet flags
+	// can't express (sarif, and the condensed "summary" view). --format
+	// json is still accepted and emits the same JSON result event as
+	// "m2arm -o json scan"; anything else defers to the ambient Reporter.
+	if cmd.Flags().Changed("format") {
+		switch opts.Format {
+		case "sarif":
+			return outputSARIF(app, results)
+		case "json":
+			report.NewJSONReporter(app.Stdout).Result(results)
+			return nil
+		case "summary":
+			return outputSummary(rep, results)
+		}
+	}
+
+	if rep.Structured() {
+		rep.Result(results)
+		return nil
 	}
+	return outputTable(rep, opts, results)
 }
 
-func outputJSON(results *sdk.ScanResults) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(results)
+func outputSARIF(app *App, results *sdk.ScanResults) error {
+	data, err := sdk.NewSARIFReporter().Report(results)
+	if err != nil {
+		return fmt.Errorf("failed to render SARIF: %w", err)
+	}
+	_, err = app.Stdout.Write(append(data, '\n'))
+	return err
 }
 
-func outputSummary(results *sdk.ScanResults) error {
-	color.Green("ğŸ“Š Scan Summary")
-	fmt.Printf("  Total files: %d\n", results.TotalFiles)
-	fmt.Printf("  Scanned files: %d\n", results.ScannedFiles)
-	fmt.Printf("  Issues found: %d\n", len(results.Issues))
-	fmt.Printf("  Dependencies: %d\n", len(results.Dependencies))
-	fmt.Printf("  Build systems: %d\n", len(results.BuildSystems))
+func outputSummary(rep report.Reporter, results *sdk.ScanResults) error {
+	rep.Section("Scan Summary")
+	rep.Info(fmt.Sprintf("Total files: %d", results.TotalFiles))
+	rep.Info(fmt.Sprintf("Scanned files: %d", results.ScannedFiles))
+	rep.Info(fmt.Sprintf("Issues found: %d", len(results.Issues)))
+	rep.Info(fmt.Sprintf("Dependencies: %d", len(results.Dependencies)))
+	rep.Info(fmt.Sprintf("Build systems: %d", len(results.BuildSystems)))
 
 	if len(results.Issues) > 0 {
-		fmt.Println("\nğŸ”´ Issue Categories:")
+		rep.Section("Issue Categories")
 		categories := make(map[string]int)
 		for _, issue := range results.Issues {
 			categories[issue.Category]++
 		}
 		for category, count := range categories {
-			fmt.Printf("  %s: %d\n", category, count)
+			rep.Info(fmt.Sprintf("%s: %d", category, count))
 		}
 	}
 
 	if len(results.Recommendations) > 0 {
-		fmt.Println("\nğŸ’¡ Recommendations:")
+		rep.Section("Recommendations")
 		for _, rec := range results.Recommendations {
-			fmt.Printf("  â€¢ %s\n", rec)
+			rep.Info(rec)
 		}
 	}
 
 	return nil
 }
 
-func outputTable(results *sdk.ScanResults) error {
-	// Header
-	color.Green("ğŸ” M2ARM Code Scan Results")
-	fmt.Printf("Scanned %d files in %s\n\n", results.ScannedFiles, time.Now().Format("2006-01-02 15:04:05"))
+func outputTable(rep report.Reporter, opts *scanOptions, results *sdk.ScanResults) error {
+	rep.Section("M2ARM Code Scan Results")
+	rep.Info(fmt.Sprintf("Scanned %d files in %s", results.ScannedFiles, results.ScanTime.Format("2006-01-02 15:04:05")))
 
-	// Issues table
 	if len(results.Issues) > 0 {
-		color.Yellow("ğŸš¨ Found %d compatibility issues:", len(results.Issues))
-		
-		t := table.NewWriter()
-		t.SetOutputMirror(os.Stdout)
-		t.AppendHeader(table.Row{"File", "Line", "Category", "Severity", "Issue"})
+		rep.Section(fmt.Sprintf("Found %d compatibility issues", len(results.Issues)))
 
-		for _, issue := range results.Issues {
-			severity := issue.Severity
-			if severity == "high" {
-				severity = color.RedString("ğŸ”´ HIGH")
-			} else if severity == "medium" {
-				severity = color.YellowString("ğŸŸ¡ MEDIUM")
-			} else {
-				severity = color.GreenString("ğŸŸ¢ LOW")
-			}
+		headers := []string{"File", "Line", "Category", "Severity", "Issue"}
+		if opts.Targets != "" {
+			headers = append(headers, "Targets")
+		}
 
+		rows := make([][]string, 0, len(results.Issues))
+		for _, issue := range results.Issues {
 			// Truncate long file paths
 			file := issue.File
 			if len(file) > 40 {
@@ -175,83 +221,52 @@ func outputTable(results *sdk.ScanResults) error {
 				matchedText = matchedText[:27] + "..."
 			}
 
-			t.AppendRow(table.Row{
-				file,
-				issue.Line,
-				issue.Category,
-				severity,
-				matchedText,
-			})
+			row := []string{file, fmt.Sprintf("%d", issue.Line), issue.Category, issue.Severity, matchedText}
+			if opts.Targets != "" {
+				row = append(row, strings.Join(issue.AffectedTargets, ", "))
+			}
+			rows = append(rows, row)
 		}
 
-		t.SetStyle(table.StyleColoredBright)
-		t.Render()
-		fmt.Println()
+		rep.Table(headers, rows)
 	} else {
-		color.Green("âœ… No obvious compatibility issues found!")
-		fmt.Println()
+		rep.Info("No obvious compatibility issues found!")
 	}
 
-	// Dependencies
 	if len(results.Dependencies) > 0 {
-		color.Cyan("ğŸ“¦ Dependencies found: %d", len(results.Dependencies))
-		
-		t := table.NewWriter()
-		t.SetOutputMirror(os.Stdout)
-		t.AppendHeader(table.Row{"Name", "Version", "Type", "ARM Support"})
+		rep.Section(fmt.Sprintf("Dependencies found: %d", len(results.Dependencies)))
 
+		rows := make([][]string, 0, len(results.Dependencies))
 		for _, dep := range results.Dependencies {
-			armSupport := dep.ARMCompatible
-			if armSupport == "unknown" {
-				armSupport = color.YellowString("â“ Unknown")
-			} else if armSupport == "yes" {
-				armSupport = color.GreenString("âœ… Yes")
-			} else {
-				armSupport = color.RedString("âŒ No")
-			}
-
-			t.AppendRow(table.Row{
-				dep.Name,
-				dep.Version,
-				dep.Type,
-				armSupport,
-			})
+			rows = append(rows, []string{dep.Name, dep.Version, dep.Type, dep.ARMCompatible})
 		}
-
-		t.SetStyle(table.StyleColoredBright)
-		t.Render()
-		fmt.Println()
+		rep.Table([]string{"Name", "Version", "Type", "ARM Support"}, rows)
 	}
 
-	// Build systems
 	if len(results.BuildSystems) > 0 {
-		color.Magenta("ğŸ”§ Build systems detected:")
+		rep.Section("Build systems detected")
 		for _, bs := range results.BuildSystems {
-			status := "âœ…"
+			status := "ok"
 			if bs.NeedsReview {
-				status = "âš ï¸ "
+				status = "needs review"
 			}
-			fmt.Printf("  %s %s (%s)\n", status, bs.File, bs.System)
+			rep.Info(fmt.Sprintf("%s (%s): %s", bs.File, bs.System, status))
 		}
-		fmt.Println()
 	}
 
-	// Recommendations
 	if len(results.Recommendations) > 0 {
-		color.Blue("ğŸ’¡ Recommendations:")
+		rep.Section("Recommendations")
 		for _, rec := range results.Recommendations {
-			fmt.Printf("  %s\n", rec)
+			rep.Info(rec)
 		}
-		fmt.Println()
 	}
 
-	// Next steps
 	if len(results.Issues) > 0 {
-		color.Blue("ğŸš€ Next Steps:")
-		fmt.Println("  1. Run 'm2arm plan' to create a migration strategy")
-		fmt.Println("  2. Review high-severity issues first")
-		fmt.Println("  3. Check dependency ARM compatibility")
-		fmt.Println("  4. Update build system configurations")
+		rep.Section("Next Steps")
+		rep.Info("1. Run 'm2arm plan' to create a migration strategy")
+		rep.Info("2. Review high-severity issues first")
+		rep.Info("3. Check dependency ARM compatibility")
+		rep.Info("4. Update build system configurations")
 	}
 
 	return nil
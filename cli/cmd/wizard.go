@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/m2arm/cli/internal/armtarget"
+	"github.com/m2arm/cli/internal/prompt"
+	"github.com/m2arm/cli/internal/report"
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// wizardTargetOptions lists the legacy target aliases offered to
+// MultiSelect, in the same order 'm2arm plan'/'m2arm migrate' document
+// them.
+var wizardTargetOptions = []string{"arm64", "armv7", "armv6", "armv5"}
+
+// newWizardCmd builds the "wizard" command wired to app. Wizard is a thin
+// orchestrator over the same Scanner/Planner/MigrationRunner the
+// individual subcommands use, so it carries no migration logic of its
+// own: every prompt only narrows down the arguments loadScanResults,
+// app.NewPlanner, and executeMigration already accept.
+func newWizardCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wizard",
+		Short: "Interactively scan, plan, and migrate a project to ARM",
+		Long: `Wizard walks a first-time user through the whole pipeline: it scans the
+project, asks which ARM variants to plan for, shows the resulting plan
+grouped by confidence, lets you choose which confidence levels to
+auto-apply, and finally runs the migration.
+
+Wizard requires an interactive terminal; non-TTY invocations (CI,
+pipes, scripts) should use 'm2arm scan', 'm2arm plan', and
+'m2arm migrate' instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWizard(app, cmd)
+		},
+	}
+
+	return cmd
+}
+
+func runWizard(app *App, cmd *cobra.Command) error {
+	rep := app.Reporter
+	pr := app.Prompter
+
+	path, err := pr.Input("Project path to migrate", ".")
+	if err != nil {
+		return fmt.Errorf("wizard: %w", err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+
+	rep.Step(fmt.Sprintf("Scanning %s", absPath))
+	results, err := loadScanResults(app, cmd, absPath, "")
+	if err != nil {
+		return err
+	}
+	if err := outputSummary(rep, results); err != nil {
+		return err
+	}
+
+	selected, err := pr.MultiSelect("Which ARM targets do you want to plan for?", wizardTargetOptions)
+	if err != nil {
+		return fmt.Errorf("wizard: %w", err)
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("wizard: no targets selected")
+	}
+
+	targets, err := armtarget.Parse(strings.Join(selected, ","))
+	if err != nil {
+		return fmt.Errorf("invalid target selection: %w", err)
+	}
+
+	plan := app.NewPlanner(targets).Plan(results)
+	printPlanByConfidence(rep, plan)
+
+	confirmedConfidence, err := confirmConfidenceLevels(pr, plan)
+	if err != nil {
+		return err
+	}
+
+	backup, err := pr.Confirm("Create a backup before applying changes?", true)
+	if err != nil {
+		return fmt.Errorf("wizard: %w", err)
+	}
+
+	apply, err := pr.Confirm("Apply the migration now?", false)
+	if err != nil {
+		return fmt.Errorf("wizard: %w", err)
+	}
+	if !apply {
+		rep.Info("Migration skipped. Run 'm2arm migrate --confirm' when you're ready.")
+		return nil
+	}
+
+	// A single working tree can only be migrated to one ARM variant at a
+	// time (the same invariant 'm2arm migrate --target' enforces), so even
+	// though the plan above compares every selected target, only one of
+	// them is actually applied here.
+	targetIdx := 0
+	if len(selected) > 1 {
+		rep.Info(fmt.Sprintf("Planned for %d targets, but a migration run rewrites this working tree for exactly one of them; the others can be migrated afterwards with 'm2arm migrate --target'.", len(selected)))
+		chosen, err := pr.MultiSelect("Which target should be migrated now?", selected)
+		if err != nil {
+			return fmt.Errorf("wizard: %w", err)
+		}
+		if len(chosen) != 1 {
+			return fmt.Errorf("wizard: choose exactly one target to migrate")
+		}
+		for i, s := range selected {
+			if s == chosen[0] {
+				targetIdx = i
+				break
+			}
+		}
+	}
+
+	variant := plan.Variants[targetIdx]
+	opts := &migrateOptions{Apply: true, Backup: backup, Target: selected[targetIdx]}
+	variantPlan := *plan
+	variantPlan.Steps = filterByConfidence(variant.ApplicableSteps, confirmedConfidence)
+	return executeMigration(cmd.Context(), app, opts, absPath, variant.Target, &variantPlan, false)
+}
+
+// printPlanByConfidence renders plan.Steps' changes grouped by
+// confidence level, so the user can decide which levels to auto-apply
+// before executeMigration runs.
+func printPlanByConfidence(rep report.Reporter, plan *sdk.MigrationPlan) {
+	rep.Section("Migration Plan")
+
+	byConfidence := groupByConfidence(plan.Steps)
+	labels := map[string]string{"high": "High", "medium": "Medium", "low": "Low"}
+	for _, level := range []string{"high", "medium", "low"} {
+		changes := byConfidence[level]
+		if len(changes) == 0 {
+			continue
+		}
+		rep.Step(fmt.Sprintf("%s confidence (%d changes)", labels[level], len(changes)))
+		for _, c := range changes {
+			rep.Info(fmt.Sprintf("  %s:%d %s -> %s", c.file, c.change.Line, c.change.Original, c.change.Replacement))
+		}
+	}
+}
+
+// fileChange pairs a CodeChange with the file its MigrationStep covers,
+// since groupByConfidence flattens Steps into one list per level.
+type fileChange struct {
+	file   string
+	change sdk.CodeChange
+}
+
+func groupByConfidence(steps []sdk.MigrationStep) map[string][]fileChange {
+	byConfidence := make(map[string][]fileChange)
+	for _, step := range steps {
+		for _, change := range step.Changes {
+			byConfidence[change.Confidence] = append(byConfidence[change.Confidence], fileChange{file: step.File, change: change})
+		}
+	}
+	return byConfidence
+}
+
+// confirmConfidenceLevels asks, for each confidence level present in
+// plan, whether its changes should be auto-applied or staged for manual
+// review, defaulting high confidence to yes and everything else to no.
+func confirmConfidenceLevels(pr prompt.Prompter, plan *sdk.MigrationPlan) (map[string]bool, error) {
+	byConfidence := groupByConfidence(plan.Steps)
+
+	confirmed := make(map[string]bool)
+	for _, level := range []string{"high", "medium", "low"} {
+		if len(byConfidence[level]) == 0 {
+			continue
+		}
+		apply, err := pr.Confirm(fmt.Sprintf("Auto-apply %s-confidence changes? (otherwise staged for manual review)", level), level == "high")
+		if err != nil {
+			return nil, fmt.Errorf("wizard: %w", err)
+		}
+		confirmed[level] = apply
+	}
+	return confirmed, nil
+}
+
+// filterByConfidence returns steps with every CodeChange whose
+// confidence level isn't in confirmed dropped, and any step left with no
+// changes removed entirely.
+func filterByConfidence(steps []sdk.MigrationStep, confirmed map[string]bool) []sdk.MigrationStep {
+	var filtered []sdk.MigrationStep
+	for _, step := range steps {
+		var changes []sdk.CodeChange
+		for _, change := range step.Changes {
+			if confirmed[change.Confidence] {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		kept := step
+		kept.Changes = changes
+		filtered = append(filtered, kept)
+	}
+	return filtered
+}
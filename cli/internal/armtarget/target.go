@@ -0,0 +1,168 @@
+// Package armtarget parses the ARM target strings 'plan', 'migrate', and
+// 'compile' accept on --target(s) into sdk.ARMTarget values, and derives
+// the concrete toolchain flags each one builds with.
+package armtarget
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// legacyAliases are the simple target names 'plan'/'migrate' accepted
+// before sub-variant targeting existed; Parse keeps resolving them to a
+// reasonable baseline ARMTarget so existing scripts and defaults still work.
+var legacyAliases = map[string]sdk.ARMTarget{
+	"arm64": {OS: "linux", Arch: "arm64", Variant: "8"},
+	"armv7": {OS: "linux", Arch: "arm", Variant: "7", FPU: "vfpv3"},
+	"armv6": {OS: "linux", Arch: "arm", Variant: "6", FPU: "vfp"},
+	"armv5": {OS: "linux", Arch: "arm", Variant: "5"},
+}
+
+// knownOS are the OS names Parse recognizes as the leading "os/" component
+// of a target string, so "arm64/v8.2" (no OS) and "linux/arm64" (no
+// variant) aren't ambiguous with each other.
+var knownOS = map[string]bool{"linux": true, "darwin": true, "windows": true, "android": true}
+
+// armVariants are the GOARM sub-variants arch=="arm" supports.
+var armVariants = map[string]bool{"5": true, "6": true, "7": true}
+
+// armFPUs are the FPU/float-ABI suffixes valid for arch=="arm".
+var armFPUs = map[string]bool{"softfp": true, "vfp": true, "vfpv3": true, "vfpv4": true, "neon": true}
+
+// arm64Features are the ISA feature suffixes valid for arch=="arm64"; arm64
+// always has NEON/ASIMD, so these describe optional extensions on top of
+// it rather than an FPU choice.
+var arm64Features = map[string]bool{"sve": true, "sve2": true, "crypto": true}
+
+// Parse parses a comma-separated list of target strings into ARMTargets.
+// Each entry is either a legacy alias ("arm64", "armv7") or
+// "[os/]arch[/variant][+fpu]", e.g. "linux/arm/7+neon" or "arm64/v8.2+sve";
+// OS defaults to "linux" and variant defaults to the architecture's
+// baseline (7 for arm, 8 for arm64) when omitted.
+func Parse(s string) ([]sdk.ARMTarget, error) {
+	var targets []sdk.ARMTarget
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		t, err := parseOne(part)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets given")
+	}
+	return targets, nil
+}
+
+// parseOne parses a single target string; see Parse for the syntax.
+func parseOne(s string) (sdk.ARMTarget, error) {
+	if alias, ok := legacyAliases[s]; ok {
+		return alias, nil
+	}
+
+	fpu := ""
+	rest := s
+	if idx := strings.Index(rest, "+"); idx != -1 {
+		fpu, rest = rest[idx+1:], rest[:idx]
+	}
+
+	fields := strings.Split(rest, "/")
+	osName := "linux"
+	if len(fields) > 1 && knownOS[fields[0]] {
+		osName = fields[0]
+		fields = fields[1:]
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return sdk.ARMTarget{}, fmt.Errorf("invalid target %q: expected [os/]arch[/variant][+fpu]", s)
+	}
+
+	arch := fields[0]
+	variant := ""
+	if len(fields) == 2 {
+		variant = strings.TrimPrefix(fields[1], "v")
+	}
+
+	switch arch {
+	case "arm":
+		if variant == "" {
+			variant = "7"
+		}
+		if !armVariants[variant] {
+			return sdk.ARMTarget{}, fmt.Errorf("invalid target %q: arm does not support variant %q (want 5, 6, or 7)", s, variant)
+		}
+		if fpu != "" && !armFPUs[fpu] {
+			return sdk.ARMTarget{}, fmt.Errorf("invalid target %q: arm does not support fpu %q", s, fpu)
+		}
+	case "arm64":
+		if variant == "" {
+			variant = "8"
+		}
+		if !strings.HasPrefix(variant, "8") {
+			return sdk.ARMTarget{}, fmt.Errorf("invalid target %q: arm64 does not support variant %q (want 8, 8.2, ...)", s, variant)
+		}
+		if fpu != "" && !arm64Features[fpu] {
+			return sdk.ARMTarget{}, fmt.Errorf("invalid target %q: arm64 does not support feature %q", s, fpu)
+		}
+	default:
+		return sdk.ARMTarget{}, fmt.Errorf("invalid target %q: unsupported architecture %q (want arm or arm64)", s, arch)
+	}
+
+	return sdk.ARMTarget{OS: osName, Arch: arch, Variant: variant, FPU: fpu}, nil
+}
+
+// BuildFlags are the toolchain-specific flags one ARMTarget compiles with.
+type BuildFlags struct {
+	// GOARM is the Go toolchain's GOARM value, set only for arch=="arm".
+	GOARM string
+
+	// MFPU is the C/C++ compiler's -mfpu value, set only when arch=="arm"
+	// and FPU names a known FPU.
+	MFPU string
+
+	// MArch is the C/C++ compiler's -march value.
+	MArch string
+
+	// CMakeSystemProcessor is the CMAKE_SYSTEM_PROCESSOR value CMake's
+	// cross-compile toolchain file needs.
+	CMakeSystemProcessor string
+}
+
+// armMFPU maps an arm ARMTarget.FPU to its -mfpu flag value; "softfp"
+// selects a float ABI, not an FPU, so it has no -mfpu entry.
+var armMFPU = map[string]string{
+	"vfp":   "vfp",
+	"vfpv3": "vfpv3",
+	"vfpv4": "vfpv4",
+	"neon":  "neon",
+}
+
+// Flags derives the concrete toolchain flags t builds with.
+func Flags(t sdk.ARMTarget) BuildFlags {
+	switch t.Arch {
+	case "arm":
+		march := "armv" + t.Variant
+		return BuildFlags{
+			GOARM:                t.Variant,
+			MFPU:                 armMFPU[t.FPU],
+			MArch:                march,
+			CMakeSystemProcessor: "arm",
+		}
+	case "arm64":
+		march := "armv" + t.Variant + "-a"
+		if t.FPU != "" {
+			march += "+" + t.FPU
+		}
+		return BuildFlags{
+			MArch:                march,
+			CMakeSystemProcessor: "aarch64",
+		}
+	default:
+		return BuildFlags{}
+	}
+}
@@ -0,0 +1,86 @@
+package armtarget
+
+import (
+	"testing"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []sdk.ARMTarget
+	}{
+		{
+			name: "legacy alias",
+			in:   "arm64",
+			want: []sdk.ARMTarget{{OS: "linux", Arch: "arm64", Variant: "8"}},
+		},
+		{
+			name: "legacy alias armv7",
+			in:   "armv7",
+			want: []sdk.ARMTarget{{OS: "linux", Arch: "arm", Variant: "7", FPU: "vfpv3"}},
+		},
+		{
+			name: "explicit os/arch/variant+fpu",
+			in:   "linux/arm/7+neon",
+			want: []sdk.ARMTarget{{OS: "linux", Arch: "arm", Variant: "7", FPU: "neon"}},
+		},
+		{
+			name: "arm64 with v-prefixed variant and feature",
+			in:   "linux/arm64/v8.2+sve",
+			want: []sdk.ARMTarget{{OS: "linux", Arch: "arm64", Variant: "8.2", FPU: "sve"}},
+		},
+		{
+			name: "no os defaults to linux",
+			in:   "arm64/v8.4",
+			want: []sdk.ARMTarget{{OS: "linux", Arch: "arm64", Variant: "8.4"}},
+		},
+		{
+			name: "comma-separated list",
+			in:   "armv7,arm64",
+			want: []sdk.ARMTarget{
+				{OS: "linux", Arch: "arm", Variant: "7", FPU: "vfpv3"},
+				{OS: "linux", Arch: "arm64", Variant: "8"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.in, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Parse(%q)[%d] = %+v, want %+v", tt.in, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "arm64 variant on arm", in: "arm/8"},
+		{name: "arm variant on arm64", in: "arm64/5"},
+		{name: "unsupported architecture", in: "mips"},
+		{name: "empty", in: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.in); err == nil {
+				t.Fatalf("Parse(%q) error = nil, want error", tt.in)
+			}
+		})
+	}
+}
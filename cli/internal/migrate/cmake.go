@@ -0,0 +1,77 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/m2arm/cli/internal/armtarget"
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// cmakeDirectives maps the descriptive build-system changes
+// internal/planner generates for cmake projects to the literal directive
+// that satisfies them for armTarget. A descriptive change with no entry
+// here (e.g. "Add ARM-specific compiler flags", which needs
+// project-specific judgment) is left out of the file for manual review
+// instead of guessed at.
+func cmakeDirectives(armTarget sdk.ARMTarget) map[string]string {
+	flags := armtarget.Flags(armTarget)
+	return map[string]string{
+		"Add ARM64 target support":                         fmt.Sprintf("set(CMAKE_OSX_ARCHITECTURES %s)", armTarget.Arch),
+		"Set CMAKE_SYSTEM_PROCESSOR for cross-compilation": fmt.Sprintf("set(CMAKE_SYSTEM_PROCESSOR %s)", flags.CMakeSystemProcessor),
+	}
+}
+
+// cmakeFlagUpdater is a Migrator that appends the CMake directives it
+// recognizes from a BuildSystemChange to the CMakeLists.txt it targets,
+// skipping any that are already present.
+type cmakeFlagUpdater struct{}
+
+// NewCMakeFlagUpdater returns a Migrator that appends known ARM directives
+// (see cmakeDirectives) to a cmake target's CMakeLists.txt.
+func NewCMakeFlagUpdater() Migrator {
+	return cmakeFlagUpdater{}
+}
+
+// Visit implements Migrator.
+func (cmakeFlagUpdater) Visit(ctx context.Context, target Target) (bool, error) {
+	if target.System != "cmake" || len(target.BuildSystemChanges) == 0 {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(target.AbsPath)
+	if err != nil {
+		return false, fmt.Errorf("reading %s: %w", target.Path, err)
+	}
+	content := string(data)
+
+	directives := cmakeDirectives(target.ARMTarget)
+	var toAppend []string
+	for _, description := range target.BuildSystemChanges {
+		directive, ok := directives[description]
+		if !ok || strings.Contains(content, directive) {
+			continue
+		}
+		toAppend = append(toAppend, directive)
+	}
+	if len(toAppend) == 0 {
+		return false, nil
+	}
+
+	if target.DryRun {
+		return true, nil
+	}
+
+	updated := content
+	if !strings.HasSuffix(updated, "\n") {
+		updated += "\n"
+	}
+	updated += strings.Join(toAppend, "\n") + "\n"
+
+	if err := os.WriteFile(target.AbsPath, []byte(updated), 0o644); err != nil {
+		return false, fmt.Errorf("writing %s: %w", target.Path, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,86 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// lineReplacer is the Migrator logic shared by IntrinsicRewriter and
+// IfdefWidener: both apply a set of high/medium-confidence CodeChanges by
+// replacing Original with Replacement on the change's Line, and leave
+// everything else (low-confidence changes, categories they don't own)
+// for manual review.
+type lineReplacer struct {
+	category string
+}
+
+// Visit rewrites every line in target.Changes whose Category matches r's
+// and whose Replacement is non-empty, writing the file back unless
+// target.DryRun is set.
+func (r lineReplacer) Visit(ctx context.Context, target Target) (bool, error) {
+	var pending []struct {
+		line        int
+		original    string
+		replacement string
+	}
+	for _, change := range target.Changes {
+		if change.Category != r.category || change.Replacement == "" {
+			continue
+		}
+		pending = append(pending, struct {
+			line        int
+			original    string
+			replacement string
+		}{change.Line, change.Original, change.Replacement})
+	}
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(target.AbsPath)
+	if err != nil {
+		return false, fmt.Errorf("reading %s: %w", target.Path, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	changed := false
+	for _, p := range pending {
+		idx := p.line - 1
+		if idx < 0 || idx >= len(lines) {
+			continue
+		}
+		if !strings.Contains(lines[idx], p.original) {
+			continue
+		}
+		lines[idx] = strings.Replace(lines[idx], p.original, p.replacement, 1)
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+
+	if target.DryRun {
+		return true, nil
+	}
+
+	if err := os.WriteFile(target.AbsPath, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		return false, fmt.Errorf("writing %s: %w", target.Path, err)
+	}
+	return true, nil
+}
+
+// NewIntrinsicRewriter returns a Migrator that replaces x86 SIMD intrinsics
+// with their NEON equivalent wherever the planner found one
+// (sdk.IntrinsicReplacements), e.g. "_mm_add_ps(a, b)" -> "vaddq_f32(a, b)".
+func NewIntrinsicRewriter() Migrator {
+	return lineReplacer{category: "x86_intrinsics"}
+}
+
+// NewIfdefWidener returns a Migrator that widens a single-arch #ifdef guard
+// (e.g. "#ifdef __x86_64__") to also accept the target ARM arch, wherever
+// the planner generated a widened Replacement for it.
+func NewIfdefWidener() Migrator {
+	return lineReplacer{category: "architecture_checks"}
+}
@@ -0,0 +1,147 @@
+// Package migrate executes an sdk.MigrationPlan against the files on disk.
+// It follows the same "visit each resource, apply a mutation, save if
+// changed" shape as Kubernetes' resource visitors: a Runner walks every
+// Target derived from the plan and hands it to each registered Migrator,
+// which mutates the file in memory and, outside dry-run, writes it back
+// only if it actually changed.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// Target is one file a Migrator may visit: its on-disk path plus whichever
+// half of the plan produced work for it. A file_migration step populates
+// Changes; a BuildSystemChange populates BuildSystemChanges. Migrators that
+// don't recognize either simply report changed=false.
+type Target struct {
+	// Path is relative to the project root, matching sdk.MigrationStep.File
+	// / sdk.BuildSystemChange.File; use AbsPath to actually read or write
+	// the file.
+	Path string
+
+	// AbsPath is Path resolved against the Runner's ProjectPath.
+	AbsPath string
+
+	// Changes are the CodeChanges a file_migration step scheduled against
+	// Path, nil for a build-system target.
+	Changes []sdk.CodeChange
+
+	// BuildSystemChanges are the descriptive changes a BuildSystemChange
+	// scheduled against Path, nil for a code target.
+	BuildSystemChanges []string
+
+	// System is the build system Path belongs to (e.g. "cmake"), set only
+	// alongside BuildSystemChanges.
+	System string
+
+	// ARMTarget is the Runner's target, so a Migrator can derive
+	// target-specific values (e.g. CMakeFlagUpdater's CMAKE_SYSTEM_PROCESSOR)
+	// instead of hardcoding one architecture.
+	ARMTarget sdk.ARMTarget
+
+	// DryRun, when true, tells a Migrator to report what it would change
+	// without writing anything back to disk.
+	DryRun bool
+}
+
+// Migrator visits a Target and, if it recognizes work scheduled against it,
+// applies that work. changed reports whether Path was (or, under
+// DryRun, would be) modified; err is any failure reading or writing Path.
+// A Migrator that doesn't recognize Target returns (false, nil).
+type Migrator interface {
+	Visit(ctx context.Context, target Target) (changed bool, err error)
+}
+
+// Result records the outcome of running every Migrator against one Target.
+type Result struct {
+	Path    string
+	Changed bool
+	Err     error
+}
+
+// Summary tallies a Runner's Results into the "changed / unchanged /
+// errored" counts reported to the user after a migration.
+type Summary struct {
+	Changed   int
+	Unchanged int
+	Errored   int
+	Results   []Result
+}
+
+// Runner applies an sdk.MigrationPlan's steps and build-system changes to
+// ProjectPath by visiting every target with every registered Migrator.
+type Runner struct {
+	ProjectPath string
+	ARMTarget   sdk.ARMTarget
+	DryRun      bool
+	Migrators   []Migrator
+}
+
+// NewRunner returns a Runner rooted at projectPath that applies migrators
+// in the order given against armTarget; the built-in migrators
+// (IntrinsicRewriter, IfdefWidener, CMakeFlagUpdater) cover disjoint
+// categories so order between them doesn't matter, but a caller's own
+// Migrator can still rely on running after or before them by position.
+func NewRunner(projectPath string, armTarget sdk.ARMTarget, dryRun bool, migrators ...Migrator) *Runner {
+	return &Runner{ProjectPath: projectPath, ARMTarget: armTarget, DryRun: dryRun, Migrators: migrators}
+}
+
+// Run visits every target derived from plan's Steps and BuildSystemChanges,
+// in plan order, returning a Summary of what changed.
+func (r *Runner) Run(ctx context.Context, plan *sdk.MigrationPlan) (Summary, error) {
+	var summary Summary
+
+	for _, target := range targetsFromPlan(plan, r.ProjectPath, r.ARMTarget, r.DryRun) {
+		result := r.visit(ctx, target)
+		summary.Results = append(summary.Results, result)
+
+		switch {
+		case result.Err != nil:
+			summary.Errored++
+		case result.Changed:
+			summary.Changed++
+		default:
+			summary.Unchanged++
+		}
+	}
+
+	return summary, nil
+}
+
+// targetsFromPlan converts a MigrationPlan's Steps and BuildSystemChanges
+// into the Targets a Runner visits, in plan order.
+func targetsFromPlan(plan *sdk.MigrationPlan, projectPath string, armTarget sdk.ARMTarget, dryRun bool) []Target {
+	targets := make([]Target, 0, len(plan.Steps)+len(plan.BuildSystemChanges))
+	for _, step := range plan.Steps {
+		targets = append(targets, Target{Path: step.File, AbsPath: filepath.Join(projectPath, step.File), Changes: step.Changes, ARMTarget: armTarget, DryRun: dryRun})
+	}
+	for _, change := range plan.BuildSystemChanges {
+		targets = append(targets, Target{Path: change.File, AbsPath: filepath.Join(projectPath, change.File), BuildSystemChanges: change.Changes, System: change.System, ARMTarget: armTarget, DryRun: dryRun})
+	}
+	return targets
+}
+
+// visit hands target to every Migrator in order, stopping at (and
+// reporting) the first error, and recording changed if any Migrator
+// reported a change.
+func (r *Runner) visit(ctx context.Context, target Target) Result {
+	result := Result{Path: target.Path}
+
+	for _, migrator := range r.Migrators {
+		changed, err := migrator.Visit(ctx, target)
+		if err != nil {
+			result.Err = fmt.Errorf("%s: %w", target.Path, err)
+			return result
+		}
+		if changed {
+			result.Changed = true
+		}
+	}
+
+	return result
+}
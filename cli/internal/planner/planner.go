@@ -0,0 +1,322 @@
+// Package planner turns a scanner.Scan's sdk.ScanResults into an actionable
+// sdk.MigrationPlan: issues grouped into per-file steps with a confidence
+// level and (where possible) a concrete replacement, build systems grouped
+// into deterministic config changes, and dependencies needing attention
+// filtered down to the ones that actually need it.
+package planner
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m2arm/cli/internal/armtarget"
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// Planner builds a MigrationPlan covering one or more ARM sub-variant
+// targets.
+type Planner struct {
+	// Targets are the architectures migration steps, the build matrix, and
+	// testing strategy are generated for, e.g. parsed from "arm64" or
+	// "linux/arm/7+neon,linux/arm64/v8.2+sve" via internal/armtarget.Parse.
+	Targets []sdk.ARMTarget
+}
+
+// New returns a Planner covering the given targets.
+func New(targets []sdk.ARMTarget) *Planner {
+	return &Planner{Targets: targets}
+}
+
+// Plan derives a MigrationPlan from results deterministically: the same
+// ScanResults and Targets always produce the same plan apart from
+// CreatedAt, which records when Plan ran, so plan output can be diffed
+// across scans.
+func (p *Planner) Plan(results *sdk.ScanResults) *sdk.MigrationPlan {
+	steps := p.buildSteps(results.Issues)
+
+	names := make([]string, len(p.Targets))
+	for i, t := range p.Targets {
+		names[i] = t.String()
+	}
+
+	plan := &sdk.MigrationPlan{
+		TargetArchitecture: strings.Join(names, ","),
+		CreatedAt:          time.Now(),
+		TotalIssues:        len(results.Issues),
+		Steps:              steps,
+		BuildSystemChanges: buildSystemChanges(results.BuildSystems),
+		DependencyUpdates:  dependencyUpdates(results.Dependencies),
+		TestingStrategy:    testingStrategy(names, results.Issues),
+		EstimatedEffort:    estimatedEffort(results.Issues),
+		Variants:           p.buildVariants(steps),
+	}
+
+	return plan
+}
+
+// buildVariants derives one VariantPlan per Target, filtering steps down to
+// the CodeChanges that actually apply to that variant.
+func (p *Planner) buildVariants(steps []sdk.MigrationStep) []sdk.VariantPlan {
+	variants := make([]sdk.VariantPlan, 0, len(p.Targets))
+	for _, t := range p.Targets {
+		flags := armtarget.Flags(t)
+		variants = append(variants, sdk.VariantPlan{
+			Target:               t,
+			GOARM:                flags.GOARM,
+			MFPU:                 flags.MFPU,
+			MArch:                flags.MArch,
+			CMakeSystemProcessor: flags.CMakeSystemProcessor,
+			ApplicableSteps:      applicableSteps(steps, t),
+		})
+	}
+	return variants
+}
+
+// applicableSteps filters steps down to the CodeChanges that apply to t,
+// dropping any step left with none.
+func applicableSteps(steps []sdk.MigrationStep, t sdk.ARMTarget) []sdk.MigrationStep {
+	filtered := make([]sdk.MigrationStep, 0, len(steps))
+	for _, step := range steps {
+		changes := make([]sdk.CodeChange, 0, len(step.Changes))
+		for _, change := range step.Changes {
+			if variantSupports(t, change.RequiresFeature) {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		step.Changes = changes
+		step.IssuesCount = len(changes)
+		filtered = append(filtered, step)
+	}
+	return filtered
+}
+
+// variantSupports reports whether t can apply a CodeChange that requires
+// feature; an empty feature means the change is unconditional.
+func variantSupports(t sdk.ARMTarget, feature string) bool {
+	switch feature {
+	case "":
+		return true
+	case "neon":
+		return t.HasNEON()
+	default:
+		return false
+	}
+}
+
+// buildSteps groups issues by file, in the order each file was first seen
+// in results.Issues, and converts each file's issues into one
+// file_migration MigrationStep.
+func (p *Planner) buildSteps(issues []sdk.Issue) []sdk.MigrationStep {
+	var files []string
+	byFile := make(map[string][]sdk.Issue)
+	for _, issue := range issues {
+		if _, ok := byFile[issue.File]; !ok {
+			files = append(files, issue.File)
+		}
+		byFile[issue.File] = append(byFile[issue.File], issue)
+	}
+
+	steps := make([]sdk.MigrationStep, 0, len(files))
+	for i, file := range files {
+		fileIssues := byFile[file]
+		changes := make([]sdk.CodeChange, 0, len(fileIssues))
+		for _, issue := range fileIssues {
+			changes = append(changes, codeChange(issue))
+		}
+
+		steps = append(steps, sdk.MigrationStep{
+			ID:          i + 1,
+			Type:        "file_migration",
+			File:        file,
+			IssuesCount: len(fileIssues),
+			Changes:     changes,
+		})
+	}
+
+	return steps
+}
+
+// archCheckPattern matches the x86 #ifdef guards the scanner's
+// architecture_checks rule pack recognizes, capturing the macro so
+// archCheckReplacement can widen it to also accept arm64/__aarch64__.
+var archCheckPattern = regexp.MustCompile(`^#ifdef\s+(__x86_64__|_M_X64|__i386__|_M_IX86)$`)
+
+// archCheckReplacement widens a single-arch #ifdef into one that also
+// accepts ARM, or "" if matched isn't a guard archCheckPattern recognizes.
+func archCheckReplacement(matched string) string {
+	groups := archCheckPattern.FindStringSubmatch(matched)
+	if groups == nil {
+		return ""
+	}
+
+	switch groups[1] {
+	case "__x86_64__", "_M_X64":
+		return fmt.Sprintf("#if defined(%s) || defined(__aarch64__)", groups[1])
+	default:
+		return fmt.Sprintf("#if defined(%s) || defined(__arm__)", groups[1])
+	}
+}
+
+// codeChange converts one Issue into a CodeChange, filling in Replacement
+// and Confidence wherever the issue's category has a known deterministic
+// fix; everything else is left "low" confidence for manual review.
+func codeChange(issue sdk.Issue) sdk.CodeChange {
+	change := sdk.CodeChange{
+		Line:     issue.Line,
+		Category: issue.Category,
+		Original: issue.MatchedText,
+	}
+
+	switch issue.Category {
+	case "x86_intrinsics":
+		if replacement, ok := sdk.IntrinsicReplacements[issue.MatchedText]; ok {
+			change.Replacement = replacement
+			change.Confidence = "high"
+			change.RequiresFeature = "neon"
+			return change
+		}
+	case "architecture_checks":
+		if replacement := archCheckReplacement(issue.MatchedText); replacement != "" {
+			change.Replacement = replacement
+			change.Confidence = "medium"
+			return change
+		}
+	}
+
+	change.Confidence = "low"
+	return change
+}
+
+// cmakeDirectives lists the descriptive build-system changes
+// buildSystemChanges emits for cmake projects. It holds descriptions only;
+// internal/migrate's CMakeFlagUpdater maps the ones it recognizes to a
+// literal CMake directive and leaves the rest for manual review.
+var cmakeDirectives = []string{
+	"Add ARM64 target support",
+	"Set CMAKE_SYSTEM_PROCESSOR for cross-compilation",
+}
+
+// buildSystemChanges converts each detected BuildSystem into the config
+// changes it needs: a fixed, deterministic set of directives for cmake
+// (which internal/migrate's CMakeFlagUpdater knows how to apply), or a
+// single manual-review note for every other build system.
+func buildSystemChanges(buildSystems []sdk.BuildSystem) []sdk.BuildSystemChange {
+	changes := make([]sdk.BuildSystemChange, 0, len(buildSystems))
+	for _, bs := range buildSystems {
+		if !bs.NeedsReview {
+			continue
+		}
+
+		if bs.System == "cmake" {
+			changes = append(changes, sdk.BuildSystemChange{
+				File:    bs.File,
+				System:  bs.System,
+				Changes: append([]string(nil), cmakeDirectives...),
+			})
+			continue
+		}
+
+		changes = append(changes, sdk.BuildSystemChange{
+			File:    bs.File,
+			System:  bs.System,
+			Changes: []string{fmt.Sprintf("Review %s for ARM-specific configuration", bs.File)},
+		})
+	}
+	return changes
+}
+
+// dependencyActions maps a Dependency.ARMCompatible verdict to the action a
+// DependencyUpdate should record; "yes" isn't here because fully compatible
+// dependencies don't need an update at all.
+var dependencyActions = map[string]string{
+	"no":          "find_arm_alternative",
+	"source-only": "plan_source_build",
+	"unknown":     "verify_arm_support",
+}
+
+// dependencyUpdates filters results.Dependencies down to the ones whose
+// ARMCompatible verdict requires action, carrying over the Resolver's Notes
+// as the explanation.
+func dependencyUpdates(dependencies []sdk.Dependency) []sdk.DependencyUpdate {
+	var updates []sdk.DependencyUpdate
+	for _, dep := range dependencies {
+		action, ok := dependencyActions[dep.ARMCompatible]
+		if !ok {
+			continue
+		}
+
+		updates = append(updates, sdk.DependencyUpdate{
+			Name:           dep.Name,
+			CurrentVersion: dep.Version,
+			Type:           dep.Type,
+			Action:         action,
+			Notes:          dep.Notes,
+		})
+	}
+	return updates
+}
+
+// focusAreasByCategory maps an Issue.Category to the testing focus area it
+// implies, so testingStrategy can derive UnitTests.FocusAreas from what was
+// actually found instead of a fixed list.
+var focusAreasByCategory = map[string]string{
+	"inline_assembly":     "low-level assembly",
+	"x86_intrinsics":      "SIMD code",
+	"architecture_checks": "architecture detection",
+	"platform_specific":   "platform API calls",
+}
+
+// testingStrategy derives a TestingStrategy from the categories of issue
+// actually found, falling back to a generic strategy when there are none.
+func testingStrategy(targets []string, issues []sdk.Issue) sdk.TestingStrategy {
+	seen := make(map[string]bool)
+	var focusAreas []string
+	for _, issue := range issues {
+		area, ok := focusAreasByCategory[issue.Category]
+		if ok && !seen[area] {
+			seen[area] = true
+			focusAreas = append(focusAreas, area)
+		}
+	}
+	sort.Strings(focusAreas)
+
+	return sdk.TestingStrategy{
+		UnitTests: sdk.TestConfig{
+			Required:   true,
+			Platforms:  append(append([]string(nil), targets...), "x86_64"),
+			FocusAreas: focusAreas,
+		},
+		IntegrationTests: sdk.TestConfig{
+			Required:     true,
+			Environments: []string{"native_arm", "emulated_arm", "cross_platform"},
+		},
+		PerformanceTests: sdk.TestConfig{
+			Required:   len(issues) > 0,
+			Metrics:    []string{"execution_time", "memory_usage", "power_consumption"},
+			Comparison: "x86_64",
+		},
+	}
+}
+
+// estimatedEffort buckets the total issue count into the same coarse
+// labels 'plan' has always reported, now driven by the real count instead
+// of a hardcoded "medium".
+func estimatedEffort(issues []sdk.Issue) string {
+	switch {
+	case len(issues) == 0:
+		return "none"
+	case len(issues) < 5:
+		return "low"
+	case len(issues) < 20:
+		return "medium"
+	default:
+		return "high"
+	}
+}
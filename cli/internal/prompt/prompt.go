@@ -0,0 +1,74 @@
+// Package prompt gives the "wizard" command a single interface to ask
+// interactive questions through, so it can be driven by a scripted fake
+// in tests instead of a real terminal.
+package prompt
+
+import (
+	"errors"
+	"os"
+
+	survey "github.com/AlecAivazis/survey/v2"
+)
+
+// ErrNotInteractive is returned by a SurveyPrompter when stdin isn't a
+// terminal, since survey's prompts hang (or render garbled output)
+// without one.
+var ErrNotInteractive = errors.New("not running in an interactive terminal; use the non-interactive scan/plan/migrate commands instead")
+
+// Prompter is how the wizard command asks interactive questions.
+type Prompter interface {
+	// Input asks for a single line of free text, returning def if the
+	// user enters nothing.
+	Input(message, def string) (string, error)
+
+	// MultiSelect asks the user to pick any number of options.
+	MultiSelect(message string, options []string) ([]string, error)
+
+	// Confirm asks a yes/no question, defaulting to def.
+	Confirm(message string, def bool) (bool, error)
+}
+
+// SurveyPrompter is the real Prompter, backed by AlecAivazis/survey.
+type SurveyPrompter struct{}
+
+// NewSurveyPrompter returns a Prompter that fails fast with
+// ErrNotInteractive when stdin isn't a terminal, rather than the wizard
+// hanging on its first question.
+func NewSurveyPrompter() *SurveyPrompter {
+	return &SurveyPrompter{}
+}
+
+func (p *SurveyPrompter) checkInteractive() error {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return ErrNotInteractive
+	}
+	return nil
+}
+
+func (p *SurveyPrompter) Input(message, def string) (string, error) {
+	if err := p.checkInteractive(); err != nil {
+		return "", err
+	}
+	var answer string
+	err := survey.AskOne(&survey.Input{Message: message, Default: def}, &answer)
+	return answer, err
+}
+
+func (p *SurveyPrompter) MultiSelect(message string, options []string) ([]string, error) {
+	if err := p.checkInteractive(); err != nil {
+		return nil, err
+	}
+	var answer []string
+	err := survey.AskOne(&survey.MultiSelect{Message: message, Options: options}, &answer)
+	return answer, err
+}
+
+func (p *SurveyPrompter) Confirm(message string, def bool) (bool, error) {
+	if err := p.checkInteractive(); err != nil {
+		return false, err
+	}
+	var answer bool
+	err := survey.AskOne(&survey.Confirm{Message: message, Default: def}, &answer)
+	return answer, err
+}
@@ -0,0 +1,377 @@
+// Package report gives every m2arm command a single place to write
+// progress and results through, instead of each one calling color.* and
+// fmt.Printf directly. A Reporter turns those calls into either the
+// existing colored terminal output, an NDJSON event stream, or a
+// structured document, so "m2arm -o json scan" and friends don't require
+// every command to grow its own --format flag.
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"gopkg.in/yaml.v3"
+)
+
+// Reporter is how a command reports its progress and final output. Every
+// method is safe to call any number of times in any order; callers don't
+// need to know which implementation is active.
+type Reporter interface {
+	// Section starts a new named part of the command's output, e.g.
+	// "Migration Steps" or "Dependency Updates".
+	Section(title string)
+
+	// Step announces a single unit of work about to run, e.g. the file a
+	// migrator is about to visit.
+	Step(name string)
+
+	// Progress starts tracking a named piece of work with a known total,
+	// returning a handle the caller advances as it completes units.
+	Progress(name string, total int) ProgressHandle
+
+	// Info reports a normal, expected message.
+	Info(msg string)
+
+	// Warn reports something the user should double-check but that isn't
+	// an error, e.g. a dependency needing manual review.
+	Warn(msg string)
+
+	// Error reports a per-item failure that doesn't necessarily abort the
+	// command, e.g. one file a migrator couldn't rewrite.
+	Error(msg string)
+
+	// Table reports tabular data, e.g. the issues a scan found.
+	Table(headers []string, rows [][]string)
+
+	// Result reports a command's final output value, e.g. a
+	// *sdk.ScanResults or *sdk.MigrationPlan.
+	Result(v interface{})
+
+	// Structured reports whether Result is this Reporter's primary output,
+	// so callers should skip any format-specific text rendering (tables,
+	// section headers) rather than doing both.
+	Structured() bool
+}
+
+// ProgressHandle tracks one Reporter.Progress call as it advances.
+type ProgressHandle interface {
+	// Add records n more completed units.
+	Add(n int)
+
+	// Done marks the tracked work as finished.
+	Done()
+}
+
+// New returns the Reporter format names ("text", "json", "yaml", "quiet")
+// select, defaulting to a TextReporter for anything else. Every Reporter
+// writes its output to out; errOut is used only by the "quiet" Reporter,
+// which sends its Error messages there.
+func New(format string, out, errOut io.Writer) Reporter {
+	switch format {
+	case "json":
+		return NewJSONReporter(out)
+	case "yaml":
+		return NewYAMLReporter(out)
+	case "quiet":
+		return NewQuietReporter(out, errOut)
+	default:
+		return NewTextReporter(out)
+	}
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying r, retrievable with
+// FromContext.
+func NewContext(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the Reporter ctx carries, or a TextReporter writing
+// to os.Stdout if none was attached (e.g. in tests that call a command
+// function directly).
+func FromContext(ctx context.Context) Reporter {
+	if r, ok := ctx.Value(contextKey{}).(Reporter); ok {
+		return r
+	}
+	return NewTextReporter(os.Stdout)
+}
+
+// TextReporter is the human-facing Reporter: colored section headers and
+// tables when its output is a terminal, plain text otherwise.
+type TextReporter struct {
+	out   io.Writer
+	isTTY bool
+}
+
+// NewTextReporter returns a TextReporter writing to out, auto-detecting
+// whether out is a terminal to decide whether to colorize and animate
+// progress.
+func NewTextReporter(out io.Writer) *TextReporter {
+	return &TextReporter{out: out, isTTY: isTerminal(out)}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func (r *TextReporter) Section(title string) {
+	if r.isTTY {
+		color.New(color.FgGreen).Fprintln(r.out, title)
+		return
+	}
+	fmt.Fprintln(r.out, title)
+}
+
+func (r *TextReporter) Step(name string) {
+	fmt.Fprintf(r.out, "  • %s\n", name)
+}
+
+func (r *TextReporter) Progress(name string, total int) ProgressHandle {
+	return &textProgress{reporter: r, name: name, total: total}
+}
+
+func (r *TextReporter) Info(msg string) {
+	fmt.Fprintln(r.out, msg)
+}
+
+func (r *TextReporter) Warn(msg string) {
+	if r.isTTY {
+		color.New(color.FgYellow).Fprintln(r.out, msg)
+		return
+	}
+	fmt.Fprintln(r.out, msg)
+}
+
+func (r *TextReporter) Error(msg string) {
+	if r.isTTY {
+		color.New(color.FgRed).Fprintln(r.out, msg)
+		return
+	}
+	fmt.Fprintln(r.out, msg)
+}
+
+func (r *TextReporter) Table(headers []string, rows [][]string) {
+	t := table.NewWriter()
+	t.SetOutputMirror(r.out)
+
+	header := make(table.Row, len(headers))
+	for i, h := range headers {
+		header[i] = h
+	}
+	t.AppendHeader(header)
+
+	for _, row := range rows {
+		r := make(table.Row, len(row))
+		for i, cell := range row {
+			r[i] = cell
+		}
+		t.AppendRow(r)
+	}
+
+	if r.isTTY {
+		t.SetStyle(table.StyleColoredBright)
+	}
+	t.Render()
+}
+
+// Result is a no-op for TextReporter: the command itself already rendered
+// its output through Section/Step/Table by the time it would call this.
+func (r *TextReporter) Result(v interface{}) {}
+
+func (r *TextReporter) Structured() bool { return false }
+
+// textProgress animates in place when attached to a terminal, and prints
+// one line per Add otherwise so piped/logged output stays readable.
+type textProgress struct {
+	reporter *TextReporter
+	name     string
+	total    int
+	done     int
+}
+
+func (p *textProgress) Add(n int) {
+	p.done += n
+	if p.reporter.isTTY {
+		fmt.Fprintf(p.reporter.out, "\r  %s: %d/%d", p.name, p.done, p.total)
+		return
+	}
+	fmt.Fprintf(p.reporter.out, "  %s: %d/%d\n", p.name, p.done, p.total)
+}
+
+func (p *textProgress) Done() {
+	if p.reporter.isTTY {
+		fmt.Fprintf(p.reporter.out, "\r  %s: %d/%d\n", p.name, p.total, p.total)
+	}
+}
+
+// structuredReporter is the shared core JSONReporter and YAMLReporter
+// build on: every call is encoded as a single document and written to
+// out, tagged with a "type" field so a stream of them can be told apart.
+type structuredReporter struct {
+	out    io.Writer
+	errOut io.Writer
+	encode func(v interface{}) ([]byte, error)
+}
+
+func (r *structuredReporter) emit(eventType string, fields map[string]interface{}) {
+	fields["type"] = eventType
+	data, err := r.encode(fields)
+	if err != nil {
+		fmt.Fprintf(r.errOut, "report: encoding %s event: %v\n", eventType, err)
+		return
+	}
+	r.out.Write(data)
+}
+
+func (r *structuredReporter) Section(title string) {
+	r.emit("section", map[string]interface{}{"title": title})
+}
+
+func (r *structuredReporter) Step(name string) {
+	r.emit("step", map[string]interface{}{"name": name})
+}
+
+func (r *structuredReporter) Progress(name string, total int) ProgressHandle {
+	r.emit("progress_start", map[string]interface{}{"name": name, "total": total})
+	return &structuredProgress{reporter: r, name: name, total: total}
+}
+
+func (r *structuredReporter) Info(msg string) {
+	r.emit("info", map[string]interface{}{"msg": msg})
+}
+
+func (r *structuredReporter) Warn(msg string) {
+	r.emit("warn", map[string]interface{}{"msg": msg})
+}
+
+func (r *structuredReporter) Error(msg string) {
+	r.emit("error", map[string]interface{}{"msg": msg})
+}
+
+func (r *structuredReporter) Table(headers []string, rows [][]string) {
+	r.emit("table", map[string]interface{}{"headers": headers, "rows": rows})
+}
+
+func (r *structuredReporter) Result(v interface{}) {
+	r.emit("result", map[string]interface{}{"data": v})
+}
+
+func (r *structuredReporter) Structured() bool { return true }
+
+type structuredProgress struct {
+	reporter *structuredReporter
+	name     string
+	total    int
+	done     int
+}
+
+func (p *structuredProgress) Add(n int) {
+	p.done += n
+	p.reporter.emit("progress", map[string]interface{}{"name": p.name, "done": p.done, "total": p.total})
+}
+
+func (p *structuredProgress) Done() {
+	p.reporter.emit("progress_done", map[string]interface{}{"name": p.name, "total": p.total})
+}
+
+// JSONReporter streams one NDJSON object per Reporter call to out, so
+// "m2arm -o json <cmd>" can be piped straight into jq or another tool.
+type JSONReporter struct {
+	structuredReporter
+}
+
+// NewJSONReporter returns a JSONReporter writing to out.
+func NewJSONReporter(out io.Writer) *JSONReporter {
+	return &JSONReporter{structuredReporter{
+		out:    out,
+		errOut: os.Stderr,
+		encode: func(v interface{}) ([]byte, error) {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			return append(data, '\n'), nil
+		},
+	}}
+}
+
+// YAMLReporter streams one "---"-separated YAML document per Reporter
+// call to out, for the root --output yaml value.
+type YAMLReporter struct {
+	structuredReporter
+}
+
+// NewYAMLReporter returns a YAMLReporter writing to out.
+func NewYAMLReporter(out io.Writer) *YAMLReporter {
+	return &YAMLReporter{structuredReporter{
+		out:    out,
+		errOut: os.Stderr,
+		encode: func(v interface{}) ([]byte, error) {
+			data, err := yaml.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			return append([]byte("---\n"), data...), nil
+		},
+	}}
+}
+
+// QuietReporter suppresses every progress/status call and prints only the
+// final Result, as compact JSON, so scripts get just the payload with
+// nothing else on stdout to filter out.
+type QuietReporter struct {
+	out    io.Writer
+	errOut io.Writer
+}
+
+// NewQuietReporter returns a QuietReporter writing its Result to out and
+// any Error to errOut.
+func NewQuietReporter(out, errOut io.Writer) *QuietReporter {
+	return &QuietReporter{out: out, errOut: errOut}
+}
+
+func (q *QuietReporter) Section(string) {}
+func (q *QuietReporter) Step(string)    {}
+
+func (q *QuietReporter) Progress(name string, total int) ProgressHandle {
+	return quietProgress{}
+}
+
+func (q *QuietReporter) Info(string) {}
+func (q *QuietReporter) Warn(string) {}
+
+func (q *QuietReporter) Error(msg string) {
+	fmt.Fprintln(q.errOut, msg)
+}
+
+func (q *QuietReporter) Table([]string, [][]string) {}
+
+func (q *QuietReporter) Result(v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fmt.Fprintf(q.errOut, "report: encoding result: %v\n", err)
+		return
+	}
+	fmt.Fprintln(q.out, string(data))
+}
+
+func (q *QuietReporter) Structured() bool { return true }
+
+type quietProgress struct{}
+
+func (quietProgress) Add(int) {}
+func (quietProgress) Done()   {}
@@ -0,0 +1,73 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONReporter_Result(t *testing.T) {
+	var out bytes.Buffer
+	NewJSONReporter(&out).Result(map[string]int{"issues": 3})
+
+	var event map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &event); err != nil {
+		t.Fatalf("unmarshaling event: %v\noutput: %s", err, out.Bytes())
+	}
+	if event["type"] != "result" {
+		t.Errorf("type = %v, want \"result\"", event["type"])
+	}
+	data, ok := event["data"].(map[string]interface{})
+	if !ok || data["issues"] != float64(3) {
+		t.Errorf("data = %v, want {\"issues\": 3}", event["data"])
+	}
+}
+
+func TestYAMLReporter_Events_AreDocumentSeparated(t *testing.T) {
+	var out bytes.Buffer
+	rep := NewYAMLReporter(&out)
+	rep.Info("first")
+	rep.Info("second")
+
+	if got := bytes.Count(out.Bytes(), []byte("---\n")); got != 2 {
+		t.Errorf("got %d \"---\" document separators, want 2 (output: %s)", got, out.Bytes())
+	}
+}
+
+func TestQuietReporter_Result_IsBareJSON(t *testing.T) {
+	var out, errOut bytes.Buffer
+	NewQuietReporter(&out, &errOut).Result(map[string]int{"issues": 3})
+
+	var data map[string]int
+	if err := json.Unmarshal(out.Bytes(), &data); err != nil {
+		t.Fatalf("Result output isn't bare JSON: %v\noutput: %s", err, out.Bytes())
+	}
+	if data["issues"] != 3 {
+		t.Errorf("issues = %d, want 3", data["issues"])
+	}
+}
+
+func TestQuietReporter_SuppressesStatusCalls(t *testing.T) {
+	var out, errOut bytes.Buffer
+	rep := NewQuietReporter(&out, &errOut)
+	rep.Section("ignored")
+	rep.Step("ignored")
+	rep.Info("ignored")
+	rep.Table([]string{"h"}, [][]string{{"r"}})
+
+	if out.Len() != 0 {
+		t.Errorf("expected no output from status calls, got: %s", out.Bytes())
+	}
+}
+
+func TestQuietReporter_ErrorGoesToErrOut(t *testing.T) {
+	var out, errOut bytes.Buffer
+	NewQuietReporter(&out, &errOut).Error("boom")
+
+	if out.Len() != 0 {
+		t.Errorf("expected no stdout output, got: %s", out.Bytes())
+	}
+	if errOut.String() != "boom\n" {
+		t.Errorf("errOut = %q, want %q", errOut.String(), "boom\n")
+	}
+}
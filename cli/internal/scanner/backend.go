@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// Backend scans a single file and reports the issues it finds. Scanner
+// dispatches each file to the Backend registered for its language (see
+// WithBackend), so callers can trade RegexBackend's speed for a precision
+// backend such as GoASTBackend or ClangBackend where false positives matter
+// more than raw throughput.
+type Backend interface {
+	// ScanFile scans filePath, which lives under projectPath, and returns the
+	// issues found in it.
+	ScanFile(projectPath, filePath string) ([]sdk.Issue, error)
+}
+
+// chainBackend runs several Backends over the same file and concatenates
+// their issues, so a precision backend (e.g. GoASTBackend) doesn't have to
+// silently drop custom rule packs layered on via WithRulePacks/WithRulesDir.
+type chainBackend struct {
+	backends []Backend
+}
+
+// ChainBackends combines backends into a single Backend that runs each of
+// them against every file and returns the union of their issues.
+func ChainBackends(backends ...Backend) Backend {
+	return &chainBackend{backends: backends}
+}
+
+// ScanFile implements Backend.
+func (b *chainBackend) ScanFile(projectPath, filePath string) ([]sdk.Issue, error) {
+	var issues []sdk.Issue
+	for _, backend := range b.backends {
+		found, err := backend.ScanFile(projectPath, filePath)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
+
+// RegexBackend is the original textual scanner: it matches each rule pack's
+// regex against every line of the file. It is fast but can false-positive on
+// matches inside comments or string literals.
+type RegexBackend struct {
+	// rulesForExt returns the compiled rules dispatched for a file
+	// extension. Scanner wires this to its own rulesForExt so RegexBackend
+	// shares the same rule packs as the rest of the scanner.
+	rulesForExt func(ext string) []*compiledRule
+}
+
+// ScanFile implements Backend.
+func (b *RegexBackend) ScanFile(projectPath, filePath string) ([]sdk.Issue, error) {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	rules := b.rulesForExt(ext)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var issues []sdk.Issue
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		for _, rule := range rules {
+			if match := rule.regex.FindString(line); match != "" {
+				relPath, _ := filepath.Rel(projectPath, filePath)
+
+				issues = append(issues, sdk.Issue{
+					File:        relPath,
+					Line:        lineNum,
+					Category:    rule.pack.Category,
+					Pattern:     rule.pack.Regex,
+					MatchedText: match,
+					Severity:    rule.pack.Severity,
+					Suggestion:  rule.pack.Suggestion,
+				})
+			}
+		}
+	}
+
+	return issues, scanner.Err()
+}
@@ -0,0 +1,86 @@
+//go:build cgo_clang
+
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-clang/clang-v15/clang"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// ClangBackend scans C/C++ translation units with libclang, reporting true
+// intrinsic call expressions and inline __asm__ statements, each at the
+// line where the AST node starts, instead of RegexBackend's textual
+// matches. It requires cgo
+// and a libclang installation, so it only builds with -tags cgo_clang.
+type ClangBackend struct {
+	// Args are extra compiler arguments (e.g. -I include paths, -std flags)
+	// passed through to libclang when parsing each translation unit.
+	Args []string
+}
+
+// NewClangBackend returns a ClangBackend backed by libclang.
+func NewClangBackend(args ...string) (*ClangBackend, error) {
+	return &ClangBackend{Args: args}, nil
+}
+
+// ScanFile implements Backend.
+func (b *ClangBackend) ScanFile(projectPath, filePath string) ([]sdk.Issue, error) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+
+	unit, err := idx.ParseTranslationUnit(filePath, b.Args, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("clang: parsing %s: %w", filePath, err)
+	}
+	defer unit.Dispose()
+
+	relPath, _ := filepath.Rel(projectPath, filePath)
+
+	var issues []sdk.Issue
+	unit.TranslationUnitCursor().Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+		if !cursor.Location().IsFromMainFile() {
+			return clang.ChildVisit_Recurse
+		}
+
+		switch cursor.Kind() {
+		case clang.Cursor_CallExpr:
+			if name := cursor.Spelling(); isX86IntrinsicCall(name) {
+				issues = append(issues, clangIssue(cursor, relPath, "x86_intrinsics", name,
+					"Replace with ARM NEON equivalents or portable alternatives"))
+			}
+		case clang.Cursor_AsmStmt, clang.Cursor_GCCAsmStmt:
+			issues = append(issues, clangIssue(cursor, relPath, "inline_assembly", "__asm__",
+				"Replace with portable C/C++ code or use ARM NEON intrinsics"))
+		}
+
+		return clang.ChildVisit_Recurse
+	})
+
+	return issues, nil
+}
+
+func clangIssue(cursor clang.Cursor, relPath, category, matchedText, suggestion string) sdk.Issue {
+	_, line, _, _ := cursor.Location().FileLocation()
+
+	return sdk.Issue{
+		File:        relPath,
+		Line:        int(line),
+		Category:    category,
+		Pattern:     "clang-ast:" + category,
+		MatchedText: matchedText,
+		Severity:    "high",
+		Suggestion:  suggestion,
+	}
+}
+
+// isX86IntrinsicCall reports whether name looks like a genuine x86 SIMD
+// intrinsic call (_mm_add_ps, _mm256_mul_epi32, ...) rather than a
+// coincidental substring match.
+func isX86IntrinsicCall(name string) bool {
+	return strings.HasPrefix(name, "_mm_") || strings.HasPrefix(name, "_mm256_") || strings.HasPrefix(name, "_mm512_")
+}
@@ -0,0 +1,24 @@
+//go:build !cgo_clang
+
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// ClangBackend is unavailable in this build. Rebuild with -tags cgo_clang
+// and a libclang installation to enable true C/C++ AST scanning.
+type ClangBackend struct{}
+
+// NewClangBackend always fails in this build; see the cgo_clang build tag.
+func NewClangBackend(args ...string) (*ClangBackend, error) {
+	return nil, fmt.Errorf("scanner: ClangBackend requires building with -tags cgo_clang and libclang installed")
+}
+
+// ScanFile implements Backend. It is unreachable since NewClangBackend
+// always fails in this build.
+func (b *ClangBackend) ScanFile(projectPath, filePath string) ([]sdk.Issue, error) {
+	return nil, fmt.Errorf("scanner: ClangBackend requires building with -tags cgo_clang and libclang installed")
+}
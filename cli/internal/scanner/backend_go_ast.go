@@ -0,0 +1,213 @@
+package scanner
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// GoASTBackend scans .go files with go/parser and go/ast instead of
+// regexes, so build tags, syscall usage, and GOARCH comparisons are detected
+// as real syntax rather than textual matches that can false-positive inside
+// a comment or string literal.
+type GoASTBackend struct{}
+
+// ScanFile implements Backend.
+func (b *GoASTBackend) ScanFile(projectPath, filePath string) ([]sdk.Issue, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	relPath, _ := filepath.Rel(projectPath, filePath)
+
+	issues := goBuildTagIssues(file, fset, relPath)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if issue, ok := goSyscallIssue(node, fset, relPath); ok {
+				issues = append(issues, issue)
+			}
+			if issue, ok := goUnsafe64BitCastIssue(node, fset, relPath); ok {
+				issues = append(issues, issue)
+			}
+		case *ast.BinaryExpr:
+			if issue, ok := goArchComparisonIssue(node, fset, relPath); ok {
+				issues = append(issues, issue)
+			}
+		}
+		return true
+	})
+
+	return issues, nil
+}
+
+// goBuildTagIssues flags //go:build and // +build constraints that name
+// amd64 or 386 without an accompanying arm/arm64 constraint in the same
+// comment, since those are the tags a single-architecture file relies on.
+func goBuildTagIssues(file *ast.File, fset *token.FileSet, relPath string) []sdk.Issue {
+	var issues []sdk.Issue
+
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			text := c.Text
+			isBuildTag := strings.HasPrefix(text, "//go:build") ||
+				strings.HasPrefix(text, "// +build") ||
+				strings.HasPrefix(text, "//+build")
+			if !isBuildTag {
+				continue
+			}
+			if !strings.Contains(text, "amd64") && !strings.Contains(text, "386") {
+				continue
+			}
+			if strings.Contains(text, "arm") {
+				continue
+			}
+
+			pos := fset.Position(c.Pos())
+			issues = append(issues, sdk.Issue{
+				File:        relPath,
+				Line:        pos.Line,
+				Category:    "architecture_checks",
+				Pattern:     "go-ast:build-tag",
+				MatchedText: text,
+				Severity:    "medium",
+				Suggestion:  "Add an arm64 build tag alongside the x86 one, or dispatch at runtime on runtime.GOARCH",
+			})
+		}
+	}
+
+	return issues
+}
+
+// goSyscallIssue flags direct syscall.Syscall*/RawSyscall* calls, whose
+// numeric syscall numbers are architecture-specific.
+func goSyscallIssue(call *ast.CallExpr, fset *token.FileSet, relPath string) (sdk.Issue, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return sdk.Issue{}, false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Name != "syscall" {
+		return sdk.Issue{}, false
+	}
+
+	switch sel.Sel.Name {
+	case "Syscall", "Syscall6", "Syscall9", "RawSyscall", "RawSyscall6":
+	default:
+		return sdk.Issue{}, false
+	}
+
+	pos := fset.Position(call.Pos())
+	return sdk.Issue{
+		File:        relPath,
+		Line:        pos.Line,
+		Category:    "platform_specific",
+		Pattern:     "go-ast:syscall-direct",
+		MatchedText: "syscall." + sel.Sel.Name,
+		Severity:    "high",
+		Suggestion:  "Direct syscall numbers are architecture-specific; use golang.org/x/sys/unix or a portable stdlib wrapper",
+	}, true
+}
+
+// goArchComparisonIssue flags runtime.GOARCH compared against a string
+// literal, so every architecture branch can be reviewed for ARM handling.
+func goArchComparisonIssue(bin *ast.BinaryExpr, fset *token.FileSet, relPath string) (sdk.Issue, bool) {
+	if bin.Op != token.EQL && bin.Op != token.NEQ {
+		return sdk.Issue{}, false
+	}
+
+	var lit *ast.BasicLit
+	switch {
+	case isRuntimeGOARCH(bin.X):
+		lit, _ = bin.Y.(*ast.BasicLit)
+	case isRuntimeGOARCH(bin.Y):
+		lit, _ = bin.X.(*ast.BasicLit)
+	default:
+		return sdk.Issue{}, false
+	}
+	if lit == nil || lit.Kind != token.STRING {
+		return sdk.Issue{}, false
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		value = lit.Value
+	}
+
+	pos := fset.Position(bin.Pos())
+	return sdk.Issue{
+		File:        relPath,
+		Line:        pos.Line,
+		Category:    "architecture_checks",
+		Pattern:     "go-ast:runtime-goarch-check",
+		MatchedText: "runtime.GOARCH == " + strconv.Quote(value),
+		Severity:    "medium",
+		Suggestion:  "Make sure the arm/arm64 branch is handled wherever GOARCH is compared explicitly",
+	}, true
+}
+
+func isRuntimeGOARCH(e ast.Expr) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "runtime" && sel.Sel.Name == "GOARCH"
+}
+
+// goUnsafe64BitCastIssue flags conversions shaped like
+// (*[8]T)(unsafe.Pointer(x)), which assume an 8-byte (64-bit) word width and
+// silently misbehave on architectures where that doesn't hold.
+func goUnsafe64BitCastIssue(call *ast.CallExpr, fset *token.FileSet, relPath string) (sdk.Issue, bool) {
+	paren, ok := call.Fun.(*ast.ParenExpr)
+	if !ok {
+		return sdk.Issue{}, false
+	}
+	star, ok := paren.X.(*ast.StarExpr)
+	if !ok {
+		return sdk.Issue{}, false
+	}
+	arrayType, ok := star.X.(*ast.ArrayType)
+	if !ok || arrayType.Len == nil {
+		return sdk.Issue{}, false
+	}
+	lenLit, ok := arrayType.Len.(*ast.BasicLit)
+	if !ok || lenLit.Kind != token.INT || lenLit.Value != "8" {
+		return sdk.Issue{}, false
+	}
+
+	if len(call.Args) != 1 {
+		return sdk.Issue{}, false
+	}
+	arg, ok := call.Args[0].(*ast.CallExpr)
+	if !ok {
+		return sdk.Issue{}, false
+	}
+	sel, ok := arg.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return sdk.Issue{}, false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Name != "unsafe" || sel.Sel.Name != "Pointer" {
+		return sdk.Issue{}, false
+	}
+
+	pos := fset.Position(call.Pos())
+	return sdk.Issue{
+		File:        relPath,
+		Line:        pos.Line,
+		Category:    "platform_specific",
+		Pattern:     "go-ast:unsafe-64bit-cast",
+		MatchedText: "unsafe.Pointer cast to an 8-byte array",
+		Severity:    "high",
+		Suggestion:  "Casts sized for a 64-bit word width assume GOARCH=amd64/arm64; guard with build tags or use encoding/binary instead",
+	}, true
+}
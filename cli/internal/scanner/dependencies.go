@@ -0,0 +1,264 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/mod/modfile"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// scanDependencies scans every dependency manifest Scanner recognizes and,
+// if dependency resolution was requested via WithDependencyResolution or
+// config["resolve_deps"], resolves each dependency's ARM compatibility
+// concurrently via s.resolver. ctx bounds (and can cancel) those registry
+// lookups the same way it bounds the parallel file scan.
+func (s *Scanner) scanDependencies(ctx context.Context) ([]sdk.Dependency, error) {
+	var dependencies []sdk.Dependency
+
+	// Check package.json (Node.js)
+	if deps, err := s.scanNpmDependencies(); err == nil {
+		dependencies = append(dependencies, deps...)
+	}
+
+	// Check requirements.txt (Python)
+	if deps, err := s.scanPythonDependencies(); err == nil {
+		dependencies = append(dependencies, deps...)
+	}
+
+	// Check Cargo.toml (Rust)
+	if deps, err := s.scanCargoDependencies(); err == nil {
+		dependencies = append(dependencies, deps...)
+	}
+
+	// Check go.mod (Go)
+	if deps, err := s.scanGoDependencies(); err == nil {
+		dependencies = append(dependencies, deps...)
+	}
+
+	if !s.resolveDependencies {
+		return dependencies, nil
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(dependencies))
+	for i := range dependencies {
+		i := i
+		go func() {
+			defer wg.Done()
+			dependencies[i] = s.resolver.Resolve(ctx, dependencies[i])
+		}()
+	}
+	wg.Wait()
+
+	return dependencies, ctx.Err()
+}
+
+// packageJSON is the subset of package.json fields scanNpmDependencies
+// needs: the three dependency maps, plus the cpu/os fields npm itself uses
+// to refuse installing a package on an unsupported architecture.
+type packageJSON struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	CPU                  []string          `json:"cpu"`
+	OS                   []string          `json:"os"`
+}
+
+// scanNpmDependencies parses package.json's dependencies, devDependencies,
+// and optionalDependencies. If the project's own package.json cpu field
+// excludes arm/arm64, every dependency is marked "no" with a note; when
+// dependency resolution is on, the Resolver still looks each one up and its
+// verdict replaces that "no".
+func (s *Scanner) scanNpmDependencies() ([]sdk.Dependency, error) {
+	data, err := os.ReadFile(filepath.Join(s.projectPath, "package.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("parsing package.json: %w", err)
+	}
+
+	var dependencies []sdk.Dependency
+	dependencies = append(dependencies, npmDepsFrom(pkg.Dependencies, false)...)
+	dependencies = append(dependencies, npmDepsFrom(pkg.DevDependencies, false)...)
+	dependencies = append(dependencies, npmDepsFrom(pkg.OptionalDependencies, true)...)
+
+	if len(pkg.CPU) > 0 && !containsAny(pkg.CPU, "arm", "arm64") {
+		note := fmt.Sprintf("package.json restricts cpu to %v", pkg.CPU)
+		for i := range dependencies {
+			dependencies[i].ARMCompatible = "no"
+			dependencies[i].Notes = append(dependencies[i].Notes, note)
+		}
+	}
+
+	return dependencies, nil
+}
+
+// npmDepsFrom converts one of package.json's dependency maps into
+// sdk.Dependency entries, sorted by name for stable output.
+func npmDepsFrom(deps map[string]string, optional bool) []sdk.Dependency {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dependencies := make([]sdk.Dependency, 0, len(names))
+	for _, name := range names {
+		dep := sdk.Dependency{Name: name, Version: deps[name], Type: "npm", ARMCompatible: "unknown"}
+		if optional {
+			dep.Notes = append(dep.Notes, "declared as an optionalDependency; a missing ARM build won't fail install")
+		}
+		dependencies = append(dependencies, dep)
+	}
+	return dependencies
+}
+
+// containsAny reports whether values contains any of candidates.
+func containsAny(values []string, candidates ...string) bool {
+	for _, v := range values {
+		for _, c := range candidates {
+			if v == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cargoManifest is the subset of Cargo.toml fields scanCargoDependencies
+// needs: the default [dependencies] table plus any
+// [target.'cfg(...)'.dependencies] tables, which Cargo only pulls in when
+// the cfg predicate matches the build target.
+type cargoManifest struct {
+	Dependencies map[string]cargoDependency `toml:"dependencies"`
+	Target       map[string]cargoTarget     `toml:"target"`
+}
+
+type cargoTarget struct {
+	Dependencies map[string]cargoDependency `toml:"dependencies"`
+}
+
+// cargoDependency decodes either of the two shapes Cargo.toml allows for a
+// dependency: a bare version string ("1.2.3") or a table
+// ({ version = "1.2.3", optional = true }).
+type cargoDependency struct {
+	Version  string
+	Optional bool
+}
+
+func (d *cargoDependency) UnmarshalTOML(data interface{}) error {
+	switch v := data.(type) {
+	case string:
+		d.Version = v
+	case map[string]interface{}:
+		if ver, ok := v["version"].(string); ok {
+			d.Version = ver
+		}
+		if opt, ok := v["optional"].(bool); ok {
+			d.Optional = opt
+		}
+	}
+	return nil
+}
+
+// scanCargoDependencies parses Cargo.toml's [dependencies] table and every
+// [target.'cfg(...)'.dependencies] table, noting the cfg predicate each
+// target-specific dependency is gated behind (e.g. only pulled in under
+// cfg(target_arch = "x86_64"), a direct signal it needs an ARM equivalent).
+func (s *Scanner) scanCargoDependencies() ([]sdk.Dependency, error) {
+	data, err := os.ReadFile(filepath.Join(s.projectPath, "Cargo.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest cargoManifest
+	if err := toml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing Cargo.toml: %w", err)
+	}
+
+	dependencies := cargoDepsFrom(manifest.Dependencies, "")
+
+	cfgs := make([]string, 0, len(manifest.Target))
+	for cfg := range manifest.Target {
+		cfgs = append(cfgs, cfg)
+	}
+	sort.Strings(cfgs)
+
+	for _, cfg := range cfgs {
+		note := fmt.Sprintf("only built under target.%s", cfg)
+		dependencies = append(dependencies, cargoDepsFrom(manifest.Target[cfg].Dependencies, note)...)
+	}
+
+	return dependencies, nil
+}
+
+// cargoDepsFrom converts one Cargo.toml dependencies table into
+// sdk.Dependency entries, sorted by name for stable output.
+func cargoDepsFrom(deps map[string]cargoDependency, cfgNote string) []sdk.Dependency {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dependencies := make([]sdk.Dependency, 0, len(names))
+	for _, name := range names {
+		dep := sdk.Dependency{Name: name, Version: deps[name].Version, Type: "cargo", ARMCompatible: "unknown"}
+		if deps[name].Optional {
+			dep.Notes = append(dep.Notes, "declared as an optional dependency")
+		}
+		if cfgNote != "" {
+			dep.Notes = append(dep.Notes, cfgNote)
+		}
+		dependencies = append(dependencies, dep)
+	}
+	return dependencies
+}
+
+// scanGoDependencies parses go.mod's require directives with
+// golang.org/x/mod/modfile. Go modules are ARMCompatible by construction
+// (the toolchain cross-compiles pure Go to arm64 natively), so unlike the
+// other ecosystems this marks every module "yes" directly instead of
+// querying a registry; Resolver.Resolve leaves the "go" type untouched.
+// Only cgo or assembly-backed modules need manual review, which is left to
+// the x86_intrinsics/inline_assembly issue categories to flag.
+func (s *Scanner) scanGoDependencies() ([]sdk.Dependency, error) {
+	path := filepath.Join(s.projectPath, "go.mod")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go.mod: %w", err)
+	}
+
+	dependencies := make([]sdk.Dependency, 0, len(file.Require))
+	for _, req := range file.Require {
+		dep := sdk.Dependency{
+			Name:          req.Mod.Path,
+			Version:       req.Mod.Version,
+			Type:          "go",
+			ARMCompatible: "yes",
+			Notes:         []string{"Go modules cross-compile for arm64 by default; review modules using cgo or asm separately"},
+		}
+		if req.Indirect {
+			dep.Notes = append(dep.Notes, "indirect dependency")
+		}
+		dependencies = append(dependencies, dep)
+	}
+
+	return dependencies, nil
+}
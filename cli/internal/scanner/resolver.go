@@ -0,0 +1,307 @@
+package scanner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// Resolver looks up a Dependency's ARM compatibility from the package
+// registry for its ecosystem (PyPI, npm, crates.io), caching every response
+// on disk so repeat scans are fast and work offline once the cache is warm.
+// PyPI and npm responses are keyed by name+version; crates.io index
+// responses cover every version and are keyed by name alone.
+type Resolver struct {
+	client   *http.Client
+	cacheDir string
+}
+
+// NewResolver returns a Resolver that caches registry responses under
+// cacheDir. An empty cacheDir disables the disk cache (every lookup hits
+// the network).
+func NewResolver(cacheDir string) *Resolver {
+	return &Resolver{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		cacheDir: cacheDir,
+	}
+}
+
+// defaultResolverCacheDir returns $XDG_CACHE_HOME/m2arm/resolver (or the
+// platform equivalent via os.UserCacheDir), or "" if no cache directory is
+// available.
+func defaultResolverCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "m2arm", "resolver")
+}
+
+// Resolve fills in dep.ARMCompatible and appends to dep.Notes by querying
+// the registry for dep.Type. Ecosystems Resolver doesn't know how to query
+// (currently just "go") are returned unchanged. A failed lookup (offline,
+// package not found, registry error, ctx cancelled) degrades to "unknown"
+// with a note rather than failing the scan.
+func (r *Resolver) Resolve(ctx context.Context, dep sdk.Dependency) sdk.Dependency {
+	verdict, notes, err := r.lookup(ctx, dep)
+	if err != nil {
+		dep.ARMCompatible = "unknown"
+		dep.Notes = append(dep.Notes, fmt.Sprintf("ARM compatibility lookup failed: %v", err))
+		return dep
+	}
+	dep.ARMCompatible = verdict
+	dep.Notes = append(dep.Notes, notes...)
+	return dep
+}
+
+func (r *Resolver) lookup(ctx context.Context, dep sdk.Dependency) (verdict string, notes []string, err error) {
+	switch dep.Type {
+	case "python":
+		return r.lookupPyPI(ctx, dep.Name, dep.Version)
+	case "npm":
+		return r.lookupNpm(ctx, dep.Name, dep.Version)
+	case "cargo":
+		return r.lookupCrate(ctx, dep.Name)
+	default:
+		return dep.ARMCompatible, nil, nil
+	}
+}
+
+// pypiRelease is the subset of PyPI's JSON API response
+// (https://pypi.org/pypi/<name>[/<version>]/json) lookupPyPI needs: the
+// list of uploaded file names, whose wheel tags reveal ARM wheel
+// availability.
+type pypiRelease struct {
+	Urls []struct {
+		Filename    string `json:"filename"`
+		Packagetype string `json:"packagetype"`
+	} `json:"urls"`
+}
+
+// lookupPyPI queries PyPI for name (at version, or its latest release if
+// version is unpinned) and classifies it by the wheel tags on file:
+// manylinux*_aarch64/musllinux*_aarch64 or an arm64 tag mean "yes", as does
+// a universal "*-none-any.whl"/"*-any.whl" (pure-Python, no compiled
+// extension); any other wheel with no ARM or universal tag means "no", an
+// sdist with no wheels at all means "source-only" (it'll build, but only
+// from source).
+func (r *Resolver) lookupPyPI(ctx context.Context, name, version string) (string, []string, error) {
+	url := fmt.Sprintf("https://pypi.org/pypi/%s/json", name)
+	if v := pypiVersion(version); v != "" {
+		url = fmt.Sprintf("https://pypi.org/pypi/%s/%s/json", name, v)
+	}
+
+	var release pypiRelease
+	if err := r.cachedGetJSON(ctx, "pypi:"+name+"@"+version, url, &release); err != nil {
+		return "", nil, err
+	}
+
+	hasWheel, hasARMWheel, hasUniversalWheel, hasSdist := false, false, false, false
+	for _, u := range release.Urls {
+		switch u.Packagetype {
+		case "sdist":
+			hasSdist = true
+		case "bdist_wheel":
+			hasWheel = true
+			if strings.Contains(u.Filename, "_aarch64") || strings.Contains(u.Filename, "arm64") {
+				hasARMWheel = true
+			} else if strings.HasSuffix(u.Filename, "-none-any.whl") || strings.HasSuffix(u.Filename, "-any.whl") {
+				hasUniversalWheel = true
+			}
+		}
+	}
+
+	switch {
+	case hasARMWheel:
+		return "yes", []string{"manylinux/musllinux aarch64 (or arm64) wheel published"}, nil
+	case hasUniversalWheel:
+		return "yes", []string{"universal (py3-none-any) wheel published; architecture-independent"}, nil
+	case hasWheel:
+		return "no", []string{"wheels published, but none for aarch64/arm64"}, nil
+	case hasSdist:
+		return "source-only", []string{"only a source distribution is published; must build from source on ARM"}, nil
+	default:
+		return "unknown", []string{"no release files found"}, nil
+	}
+}
+
+// pypiVersion strips a requirements.txt version specifier (">=1.2.3", "*")
+// down to the bare version PyPI's per-release endpoint expects, or "" to
+// mean "use the latest release".
+func pypiVersion(version string) string {
+	version = strings.TrimLeft(version, "=><~! ")
+	if version == "" || version == "*" {
+		return ""
+	}
+	return version
+}
+
+// npmPackument is the subset of an npm registry packument lookupNpm needs:
+// the cpu field npm itself checks before installing on an unsupported
+// architecture.
+type npmPackument struct {
+	CPU []string `json:"cpu"`
+}
+
+// lookupNpm queries the npm registry for name at ref (or "latest" if ref
+// isn't a resolvable exact version) and checks its cpu field: no cpu field
+// means no restriction (portable), and arm/arm64 in cpu means it's
+// explicitly supported.
+func (r *Resolver) lookupNpm(ctx context.Context, name, version string) (string, []string, error) {
+	ref := npmRef(version)
+	url := fmt.Sprintf("https://registry.npmjs.org/%s/%s", name, ref)
+
+	var pkg npmPackument
+	if err := r.cachedGetJSON(ctx, "npm:"+name+"@"+ref, url, &pkg); err != nil {
+		return "", nil, err
+	}
+
+	if len(pkg.CPU) == 0 {
+		return "yes", []string{"no cpu restriction declared; assumed portable"}, nil
+	}
+	if containsAny(pkg.CPU, "arm", "arm64") {
+		return "yes", []string{fmt.Sprintf("cpu field allows %v", pkg.CPU)}, nil
+	}
+	return "no", []string{fmt.Sprintf("cpu field restricts to %v", pkg.CPU)}, nil
+}
+
+// npmRef reduces a package.json version range to something the npm
+// registry's single-version endpoint accepts: leading range operators
+// (^, ~, >=, <) are stripped so "^1.2.3" becomes "1.2.3", while an empty
+// version, "*", or a compound range (containing a space or "|") falls back
+// to "latest".
+func npmRef(version string) string {
+	trimmed := strings.TrimLeft(version, "^~>=< ")
+	if trimmed == "" || trimmed == "*" || strings.ContainsAny(trimmed, " |") {
+		return "latest"
+	}
+	return trimmed
+}
+
+// crateIndexEntry is one line of cargo's sparse registry index response
+// (https://index.crates.io/<index-path>/<name>): one JSON object per
+// published version. A non-empty Links means the crate links a native C
+// library via a build script — the main source of ARM build friction for an
+// otherwise portable Rust crate.
+type crateIndexEntry struct {
+	Vers   string `json:"vers"`
+	Yanked bool   `json:"yanked"`
+	Links  string `json:"links"`
+}
+
+// lookupCrate queries cargo's sparse registry index for name's most recent
+// non-yanked version and checks whether it links a native library.
+func (r *Resolver) lookupCrate(ctx context.Context, name string) (string, []string, error) {
+	url := fmt.Sprintf("https://index.crates.io/%s/%s", cratesIndexPath(name), name)
+	body, err := r.cachedGetRaw(ctx, "crate:"+name, url)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var latest *crateIndexEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry crateIndexEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		if entry.Yanked {
+			continue
+		}
+		copied := entry
+		latest = &copied
+	}
+
+	if latest == nil {
+		return "unknown", []string{"no published (non-yanked) versions found"}, nil
+	}
+	if latest.Links != "" {
+		return "source-only", []string{fmt.Sprintf("links native library %q via a build script; verify it cross-compiles for ARM", latest.Links)}, nil
+	}
+	return "yes", []string{"pure Rust crate with no native library links"}, nil
+}
+
+// cratesIndexPath mirrors cargo's sparse-index layout rules: 1- and
+// 2-character names live directly under a directory named for their
+// length, a 3-character name adds a directory for its first character, and
+// everything else nests under its first two characters then next two.
+func cratesIndexPath(name string) string {
+	switch {
+	case len(name) == 1:
+		return "1"
+	case len(name) == 2:
+		return "2"
+	case len(name) == 3:
+		return "3/" + name[:1]
+	default:
+		return name[:2] + "/" + name[2:4]
+	}
+}
+
+// cachedGetJSON is cachedGetRaw followed by a JSON decode into v.
+func (r *Resolver) cachedGetJSON(ctx context.Context, cacheKey, url string, v interface{}) error {
+	body, err := r.cachedGetRaw(ctx, cacheKey, url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// cachedGetRaw returns the body of a GET to url, keyed in the disk cache
+// under cacheDir by cacheKey. A cache hit never touches the network, which
+// is what keeps repeat scans offline-capable. ctx bounds the network
+// request so a caller (e.g. a cancelled scan) isn't stuck behind the
+// client's 10s timeout.
+func (r *Resolver) cachedGetRaw(ctx context.Context, cacheKey, url string) ([]byte, error) {
+	if r.cacheDir != "" {
+		if data, err := os.ReadFile(r.cachePath(cacheKey)); err == nil {
+			return data, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.cacheDir != "" {
+		if err := os.MkdirAll(r.cacheDir, 0o755); err == nil {
+			_ = os.WriteFile(r.cachePath(cacheKey), body, 0o644)
+		}
+	}
+
+	return body, nil
+}
+
+// cachePath returns the on-disk path cacheKey is stored under: its sha256
+// hex digest, so arbitrary registry names/versions are always a safe
+// filename.
+func (r *Resolver) cachePath(cacheKey string) string {
+	sum := sha256.Sum256([]byte(cacheKey))
+	return filepath.Join(r.cacheDir, fmt.Sprintf("%x.json", sum))
+}
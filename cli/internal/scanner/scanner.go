@@ -2,60 +2,198 @@ package scanner
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/m2arm/cli/internal/sdk"
 )
 
+// anyLanguage is the Language value (or lack thereof) meaning a rule applies
+// to every scanned file regardless of extension.
+const anyLanguage = "*"
+
+// RulePack defines a single pattern-matching rule used by the regex scanner.
+// Packs can be shipped built-in (the x86 patterns below), loaded from
+// YAML/JSON files under a rules directory, or supplied programmatically via
+// WithRulePacks, e.g. to add CUDA->ROCm, SSE->NEON, or AVX-512->SVE checks.
+type RulePack struct {
+	ID         string `yaml:"id" json:"id"`
+	Category   string `yaml:"category" json:"category"`
+	Severity   string `yaml:"severity" json:"severity"`
+	Language   string `yaml:"language" json:"language"`
+	Regex      string `yaml:"regex" json:"regex"`
+	Suggestion string `yaml:"suggestion" json:"suggestion"`
+}
+
+// languages splits the (possibly comma-separated) Language field into the
+// normalized list of languages this pack should be dispatched for. An empty
+// Language means the pack applies to every language.
+func (p RulePack) languages() []string {
+	if strings.TrimSpace(p.Language) == "" {
+		return nil
+	}
+
+	parts := strings.Split(p.Language, ",")
+	langs := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.ToLower(strings.TrimSpace(part)); part != "" {
+			langs = append(langs, part)
+		}
+	}
+	return langs
+}
+
+// rulePackFile is the on-disk shape of a rules/*.yaml or rules/*.json file.
+type rulePackFile struct {
+	Rules []RulePack `yaml:"rules" json:"rules"`
+}
+
+// compiledRule pairs a RulePack with its regex, compiled once at load time.
+type compiledRule struct {
+	pack  RulePack
+	regex *regexp.Regexp
+}
+
+// Option configures a Scanner at construction time.
+type Option func(*Scanner) error
+
+// WithRulePacks layers additional rule packs on top of the built-in x86 pack.
+func WithRulePacks(packs ...RulePack) Option {
+	return func(s *Scanner) error {
+		s.rulePacks = append(s.rulePacks, packs...)
+		return nil
+	}
+}
+
+// WithRulesDir loads every *.yaml, *.yml, and *.json file in dir as a rule
+// pack file and adds its rules on top of the built-in pack.
+func WithRulesDir(dir string) Option {
+	return func(s *Scanner) error {
+		packs, err := loadRulePacksDir(dir)
+		if err != nil {
+			return fmt.Errorf("failed to load rule packs from %s: %w", dir, err)
+		}
+		s.rulePacks = append(s.rulePacks, packs...)
+		return nil
+	}
+}
+
+// ProgressEvent reports that a single file has finished scanning.
+type ProgressEvent struct {
+	File      string
+	Completed int
+	Total     int
+}
+
+// WithProgress sets a channel that receives a ProgressEvent after each file
+// finishes scanning. Sends are non-blocking, so a slow or absent consumer
+// never stalls the scan.
+func WithProgress(ch chan<- ProgressEvent) Option {
+	return func(s *Scanner) error {
+		s.progress = ch
+		return nil
+	}
+}
+
+// WithTargets declares the build-context matrix (GOOS/GOARCH/CgoEnabled
+// tuples) the scan evaluates guards against. When set, every Issue's
+// AffectedTargets field is populated with the subset of these targets whose
+// go:build tags, #ifdef guards, or CMake if(CMAKE_SYSTEM_PROCESSOR ...)
+// blocks actually reach it. With no targets declared, AffectedTargets is
+// left nil everywhere, matching the scanner's pre-existing grep-like
+// behavior.
+func WithTargets(targets ...BuildTarget) Option {
+	return func(s *Scanner) error {
+		s.targets = append(s.targets, targets...)
+		return nil
+	}
+}
+
 // Scanner handles code scanning for x86-specific patterns
 type Scanner struct {
 	projectPath string
 	config      map[string]interface{}
-	
-	// Pattern definitions for x86-specific code
-	patterns map[string][]string
-	
+
+	// rulePacks are the raw rule definitions supplied via options, before
+	// compilation.
+	rulePacks []RulePack
+
+	// rules holds the compiled regexes grouped by language (plus anyLanguage)
+	// so scanFile can dispatch only the rules relevant to each file's
+	// extension instead of recompiling and retrying every pattern per line.
+	rules map[string][]*compiledRule
+
 	// File extensions to scan
 	extensions map[string]bool
+
+	// progress, if set via WithProgress, receives a ProgressEvent as each
+	// file finishes scanning.
+	progress chan<- ProgressEvent
+
+	// backends maps a language (as returned by languageForExt, or
+	// anyLanguage for the fallback) to the Backend used to scan it.
+	backends map[string]Backend
+
+	// targets are the build contexts declared via WithTargets that each
+	// Issue's AffectedTargets is evaluated against. Nil means the scan
+	// doesn't reason about reachability at all.
+	targets []BuildTarget
+
+	// resolver looks up each scanned Dependency's ARM compatibility.
+	// Defaults to a Resolver caching under defaultResolverCacheDir(); pass
+	// WithResolver to point it elsewhere or swap in a test double.
+	resolver *Resolver
+
+	// resolveDependencies controls whether scanDependencies actually queries
+	// resolver for each dependency's ARM compatibility. Off by default,
+	// since registry lookups are network calls that can each take up to
+	// resolver's 10s timeout; pass WithDependencyResolution(true) or set
+	// config["resolve_deps"] to opt in.
+	resolveDependencies bool
+}
+
+// WithResolver overrides the Resolver used to populate Dependency.ARMCompatible,
+// e.g. to point the disk cache elsewhere or inject a test double instead of
+// hitting PyPI/npm/crates.io over the network.
+func WithResolver(resolver *Resolver) Option {
+	return func(s *Scanner) error {
+		s.resolver = resolver
+		return nil
+	}
 }
 
-// New creates a new scanner instance
-func New(projectPath string, config map[string]interface{}) *Scanner {
+// WithDependencyResolution turns dependency ARM-compatibility resolution on
+// or off. It is off by default: resolving every dependency against its
+// registry is a network call per dependency, so a scan only pays for it
+// when explicitly asked.
+func WithDependencyResolution(enabled bool) Option {
+	return func(s *Scanner) error {
+		s.resolveDependencies = enabled
+		return nil
+	}
+}
+
+// New creates a new scanner instance. The built-in x86 rule pack is always
+// loaded; pass WithRulePacks and/or WithRulesDir to layer on custom packs
+// (e.g. CUDA->ROCm, SSE->NEON, AVX-512->SVE). If config["rules_dir"] is set,
+// it is loaded as if passed to WithRulesDir.
+func New(projectPath string, config map[string]interface{}, opts ...Option) (*Scanner, error) {
 	scanner := &Scanner{
 		projectPath: projectPath,
 		config:      config,
-		patterns: map[string][]string{
-			"inline_assembly": {
-				`__asm__\s*\(`,
-				`asm\s*\(`,
-				`_asm\s*{`,
-			},
-			"x86_intrinsics": {
-				`#include\s*<.*mmintrin\.h.*>`,
-				`#include\s*<.*xmmintrin\.h.*>`,
-				`#include\s*<.*emmintrin\.h.*>`,
-				`#include\s*<.*pmmintrin\.h.*>`,
-				`#include\s*<.*immintrin\.h.*>`,
-				`_mm_\w+`,
-				`_mm\d+_\w+`,
-			},
-			"architecture_checks": {
-				`#ifdef\s+_M_X64`,
-				`#ifdef\s+__x86_64__`,
-				`#ifdef\s+_M_IX86`,
-				`#ifdef\s+__i386__`,
-			},
-			"platform_specific": {
-				`GetSystemInfo`,
-				`IsWow64Process`,
-				`SYSTEM_INFO`,
-			},
-		},
+		rulePacks:   append([]RulePack(nil), builtinRulePacks()...),
+		resolver:    NewResolver(defaultResolverCacheDir()),
 		extensions: map[string]bool{
 			".c":    true,
 			".cpp":  true,
@@ -76,11 +214,218 @@ func New(projectPath string, config map[string]interface{}) *Scanner {
 		},
 	}
 
-	return scanner
+	regexBackend := &RegexBackend{rulesForExt: scanner.rulesForExt}
+	scanner.backends = map[string]Backend{
+		anyLanguage: regexBackend,
+		// Go files still run the regex rules (so a custom rule pack with
+		// language: go is honored) alongside the AST backend, which only
+		// covers the syntax-aware checks in backend_go_ast.go.
+		"go": ChainBackends(&GoASTBackend{}, regexBackend),
+	}
+
+	if dir, ok := config["rules_dir"].(string); ok && dir != "" {
+		opts = append([]Option{WithRulesDir(dir)}, opts...)
+	}
+	if resolveDeps, ok := config["resolve_deps"].(bool); ok {
+		opts = append([]Option{WithDependencyResolution(resolveDeps)}, opts...)
+	}
+
+	for _, opt := range opts {
+		if err := opt(scanner); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := scanner.compileRulePacks(); err != nil {
+		return nil, err
+	}
+
+	return scanner, nil
+}
+
+// WithBackend overrides the Backend used for a language (as returned by
+// languageForExt, or anyLanguage to replace the fallback used for every
+// language without a specific entry), e.g. WithBackend("cpp", clangBackend)
+// to trade RegexBackend's speed for ClangBackend's precision.
+func WithBackend(language string, backend Backend) Option {
+	return func(s *Scanner) error {
+		if s.backends == nil {
+			s.backends = make(map[string]Backend)
+		}
+		s.backends[language] = backend
+		return nil
+	}
+}
+
+// backendForExt returns the Backend registered for a file extension's
+// language, falling back to the backend registered for anyLanguage.
+func (s *Scanner) backendForExt(ext string) Backend {
+	if backend, ok := s.backends[languageForExt(ext)]; ok {
+		return backend
+	}
+	return s.backends[anyLanguage]
+}
+
+// compileRulePacks compiles every loaded rule pack's regex exactly once and
+// indexes the results by language so scanFile never recompiles a pattern.
+func (s *Scanner) compileRulePacks() error {
+	rules := make(map[string][]*compiledRule)
+
+	for _, pack := range s.rulePacks {
+		regex, err := regexp.Compile(pack.Regex)
+		if err != nil {
+			return fmt.Errorf("rule pack %q: invalid regex %q: %w", pack.ID, pack.Regex, err)
+		}
+
+		rule := &compiledRule{pack: pack, regex: regex}
+
+		languages := pack.languages()
+		if len(languages) == 0 {
+			rules[anyLanguage] = append(rules[anyLanguage], rule)
+			continue
+		}
+
+		for _, lang := range languages {
+			rules[lang] = append(rules[lang], rule)
+		}
+	}
+
+	s.rules = rules
+	return nil
+}
+
+// rulesForExt returns the rules dispatched for a file extension: those
+// tagged for the matching language plus any rules that apply to every
+// language. The extension is first mapped to a language via languageForExt.
+func (s *Scanner) rulesForExt(ext string) []*compiledRule {
+	generic := s.rules[anyLanguage]
+	specific := s.rules[languageForExt(ext)]
+
+	switch {
+	case len(generic) == 0:
+		return specific
+	case len(specific) == 0:
+		return generic
+	}
+
+	combined := make([]*compiledRule, 0, len(generic)+len(specific))
+	combined = append(combined, generic...)
+	combined = append(combined, specific...)
+	return combined
+}
+
+// languageForExt maps a (lowercased) file extension to the language tag used
+// to dispatch rule packs, so e.g. a C intrinsic regex is never tried against
+// a .py or .js file.
+func languageForExt(ext string) string {
+	switch ext {
+	case ".c", ".h":
+		return "c"
+	case ".cpp", ".cc", ".cxx", ".hpp", ".hxx":
+		return "cpp"
+	case ".py":
+		return "python"
+	case ".go":
+		return "go"
+	case ".rs":
+		return "rust"
+	case ".java":
+		return "java"
+	case ".cs":
+		return "csharp"
+	case ".js", ".jsx":
+		return "javascript"
+	case ".ts", ".tsx":
+		return "typescript"
+	default:
+		return ""
+	}
+}
+
+// loadRulePacksDir reads every *.yaml, *.yml, and *.json file directly under
+// dir and decodes it as a rulePackFile.
+func loadRulePacksDir(dir string) ([]RulePack, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var packs []RulePack
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		var file rulePackFile
+		if ext == ".json" {
+			err = json.Unmarshal(data, &file)
+		} else {
+			err = yaml.Unmarshal(data, &file)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+
+		packs = append(packs, file.Rules...)
+	}
+
+	return packs, nil
+}
+
+// builtinRulePacks ships the original x86 detection patterns as a rule pack,
+// so a fresh Scanner behaves exactly as before even with no extra packs
+// loaded.
+func builtinRulePacks() []RulePack {
+	const asmSuggestion = "Replace with portable C/C++ code or use ARM NEON intrinsics"
+	const intrinsicsSuggestion = "Replace with ARM NEON equivalents or portable alternatives"
+	const archCheckSuggestion = "Add ARM architecture checks or use runtime detection"
+	const platformSuggestion = "Use cross-platform alternatives or add ARM-specific implementations"
+
+	return []RulePack{
+		{ID: "x86-asm-gcc", Category: "inline_assembly", Severity: "high", Language: "c,cpp", Regex: `__asm__\s*\(`, Suggestion: asmSuggestion},
+		{ID: "x86-asm-generic", Category: "inline_assembly", Severity: "high", Language: "c,cpp", Regex: `asm\s*\(`, Suggestion: asmSuggestion},
+		{ID: "x86-asm-msvc", Category: "inline_assembly", Severity: "high", Language: "c,cpp", Regex: `_asm\s*{`, Suggestion: asmSuggestion},
+
+		{ID: "x86-intrinsics-header-mmx", Category: "x86_intrinsics", Severity: "high", Language: "c,cpp", Regex: `#include\s*<.*mmintrin\.h.*>`, Suggestion: intrinsicsSuggestion},
+		{ID: "x86-intrinsics-header-sse", Category: "x86_intrinsics", Severity: "high", Language: "c,cpp", Regex: `#include\s*<.*xmmintrin\.h.*>`, Suggestion: intrinsicsSuggestion},
+		{ID: "x86-intrinsics-header-sse2", Category: "x86_intrinsics", Severity: "high", Language: "c,cpp", Regex: `#include\s*<.*emmintrin\.h.*>`, Suggestion: intrinsicsSuggestion},
+		{ID: "x86-intrinsics-header-sse3", Category: "x86_intrinsics", Severity: "high", Language: "c,cpp", Regex: `#include\s*<.*pmmintrin\.h.*>`, Suggestion: intrinsicsSuggestion},
+		{ID: "x86-intrinsics-header-avx", Category: "x86_intrinsics", Severity: "high", Language: "c,cpp", Regex: `#include\s*<.*immintrin\.h.*>`, Suggestion: intrinsicsSuggestion},
+		{ID: "x86-intrinsics-mm", Category: "x86_intrinsics", Severity: "high", Language: "c,cpp", Regex: `_mm_\w+`, Suggestion: intrinsicsSuggestion},
+		{ID: "x86-intrinsics-mm-width", Category: "x86_intrinsics", Severity: "high", Language: "c,cpp", Regex: `_mm\d+_\w+`, Suggestion: intrinsicsSuggestion},
+
+		{ID: "x86-arch-check-msvc-x64", Category: "architecture_checks", Severity: "medium", Language: "c,cpp", Regex: `#ifdef\s+_M_X64`, Suggestion: archCheckSuggestion},
+		{ID: "x86-arch-check-gcc-x64", Category: "architecture_checks", Severity: "medium", Language: "c,cpp", Regex: `#ifdef\s+__x86_64__`, Suggestion: archCheckSuggestion},
+		{ID: "x86-arch-check-msvc-x86", Category: "architecture_checks", Severity: "medium", Language: "c,cpp", Regex: `#ifdef\s+_M_IX86`, Suggestion: archCheckSuggestion},
+		{ID: "x86-arch-check-gcc-x86", Category: "architecture_checks", Severity: "medium", Language: "c,cpp", Regex: `#ifdef\s+__i386__`, Suggestion: archCheckSuggestion},
+
+		{ID: "win32-getsysteminfo", Category: "platform_specific", Severity: "medium", Language: "c,cpp", Regex: `GetSystemInfo`, Suggestion: platformSuggestion},
+		{ID: "win32-iswow64process", Category: "platform_specific", Severity: "medium", Language: "c,cpp", Regex: `IsWow64Process`, Suggestion: platformSuggestion},
+		{ID: "win32-system-info-struct", Category: "platform_specific", Severity: "medium", Language: "c,cpp", Regex: `SYSTEM_INFO`, Suggestion: platformSuggestion},
+	}
 }
 
-// Scan performs the code scanning
+// Scan performs the code scanning using a background context.
 func (s *Scanner) Scan() (*sdk.ScanResults, error) {
+	return s.ScanContext(context.Background())
+}
+
+// ScanContext performs the code scanning, distributing file scans across a
+// worker pool so large projects scan in close to linear speedup. The scan
+// can be cancelled mid-flight via ctx, which is useful for running inside a
+// CI job with a timeout.
+func (s *Scanner) ScanContext(ctx context.Context) (*sdk.ScanResults, error) {
 	results := &sdk.ScanResults{
 		ScanTime: time.Now(),
 	}
@@ -93,18 +438,11 @@ func (s *Scanner) Scan() (*sdk.ScanResults, error) {
 
 	results.TotalFiles = len(files)
 
-	// Scan each file
-	for _, file := range files {
-		issues, err := s.scanFile(file)
-		if err != nil {
-			if s.isVerbose() {
-				fmt.Fprintf(os.Stderr, "Warning: failed to scan %s: %v\n", file, err)
-			}
-			continue
-		}
-
-		results.Issues = append(results.Issues, issues...)
-		results.ScannedFiles++
+	issues, scanned, err := s.scanFilesParallel(ctx, files)
+	results.Issues = issues
+	results.ScannedFiles = scanned
+	if err != nil {
+		return nil, err
 	}
 
 	// Scan build systems
@@ -115,7 +453,7 @@ func (s *Scanner) Scan() (*sdk.ScanResults, error) {
 	results.BuildSystems = buildSystems
 
 	// Scan dependencies
-	dependencies, err := s.scanDependencies()
+	dependencies, err := s.scanDependencies(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan dependencies: %w", err)
 	}
@@ -127,6 +465,106 @@ func (s *Scanner) Scan() (*sdk.ScanResults, error) {
 	return results, nil
 }
 
+// scanFilesParallel feeds files to a pool of workers sized by workerCount,
+// aggregating their issues under a mutex and emitting a ProgressEvent per
+// file. It stops feeding new work once ctx is done, but lets in-flight files
+// finish before returning.
+func (s *Scanner) scanFilesParallel(ctx context.Context, files []string) ([]sdk.Issue, int, error) {
+	workers := s.workerCount()
+	paths := make(chan string)
+
+	var (
+		mu        sync.Mutex
+		issues    []sdk.Issue
+		scanned   int
+		completed int
+	)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				fileIssues, err := s.scanFile(path)
+				if err != nil && s.isVerbose() {
+					fmt.Fprintf(os.Stderr, "Warning: failed to scan %s: %v\n", path, err)
+				}
+
+				mu.Lock()
+				if err == nil {
+					issues = append(issues, fileIssues...)
+					scanned++
+				}
+				completed++
+				done := completed
+				mu.Unlock()
+
+				s.emitProgress(path, done, len(files))
+			}
+		}()
+	}
+
+feed:
+	for _, file := range files {
+		select {
+		case <-ctx.Done():
+			break feed
+		case paths <- file:
+		}
+	}
+	close(paths)
+	wg.Wait()
+
+	sortIssues(issues)
+
+	if err := ctx.Err(); err != nil {
+		return issues, scanned, err
+	}
+
+	return issues, scanned, nil
+}
+
+// sortIssues orders issues by (File, Line, Category) so the scan's output
+// is deterministic regardless of which worker finishes a file first, rather
+// than reflecting scanFilesParallel's completion order.
+func sortIssues(issues []sdk.Issue) {
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Category < b.Category
+	})
+}
+
+// workerCount returns the worker pool size: config["workers"] if set to a
+// positive int, otherwise runtime.NumCPU().
+func (s *Scanner) workerCount() int {
+	if workers, ok := s.config["workers"].(int); ok && workers > 0 {
+		return workers
+	}
+	if n := runtime.NumCPU(); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// emitProgress sends a ProgressEvent on the progress channel, if one was
+// configured via WithProgress. The send never blocks the scan.
+func (s *Scanner) emitProgress(file string, completed, total int) {
+	if s.progress == nil {
+		return
+	}
+	select {
+	case s.progress <- ProgressEvent{File: file, Completed: completed, Total: total}:
+	default:
+	}
+}
+
 // getSourceFiles returns a list of source files to scan
 func (s *Scanner) getSourceFiles() ([]string, error) {
 	var files []string
@@ -148,9 +586,11 @@ func (s *Scanner) getSourceFiles() ([]string, error) {
 			return nil
 		}
 
-		// Check if file extension should be scanned
+		// Check if file extension should be scanned, or if it's a build
+		// file (e.g. CMakeLists.txt) whose guards matter for AffectedTargets
+		// even though it has no extension of its own.
 		ext := strings.ToLower(filepath.Ext(path))
-		if s.extensions[ext] {
+		if s.extensions[ext] || info.Name() == "CMakeLists.txt" {
 			files = append(files, path)
 		}
 
@@ -160,77 +600,19 @@ func (s *Scanner) getSourceFiles() ([]string, error) {
 	return files, err
 }
 
-// scanFile scans a single file for x86-specific patterns
+// scanFile scans a single file, dispatching it to the Backend registered for
+// its extension (GoASTBackend chained with RegexBackend for .go files and
+// RegexBackend for everything else by default, or whatever backend was
+// configured via WithBackend, e.g. ClangBackend). If
+// targets were declared via WithTargets, each issue's AffectedTargets is
+// then filled in from the build tags/guards active at its line.
 func (s *Scanner) scanFile(filePath string) ([]sdk.Issue, error) {
-	file, err := os.Open(filePath)
+	ext := strings.ToLower(filepath.Ext(filePath))
+	issues, err := s.backendForExt(ext).ScanFile(s.projectPath, filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	var issues []sdk.Issue
-	scanner := bufio.NewScanner(file)
-	lineNum := 0
-
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
-
-		// Check each category of patterns
-		for category, patterns := range s.patterns {
-			for _, pattern := range patterns {
-				regex, err := regexp.Compile(pattern)
-				if err != nil {
-					continue // Skip invalid regex
-				}
-
-				if match := regex.FindString(line); match != "" {
-					relPath, _ := filepath.Rel(s.projectPath, filePath)
-					
-					issue := sdk.Issue{
-						File:        relPath,
-						Line:        lineNum,
-						Category:    category,
-						Pattern:     pattern,
-						MatchedText: match,
-						Severity:    s.getSeverity(category),
-						Suggestion:  s.getSuggestion(category, match),
-					}
-					issues = append(issues, issue)
-				}
-			}
-		}
-	}
-
-	return issues, scanner.Err()
-}
-
-// getSeverity returns the severity level for an issue category
-func (s *Scanner) getSeverity(category string) string {
-	severityMap := map[string]string{
-		"inline_assembly":     "high",
-		"x86_intrinsics":     "high",
-		"architecture_checks": "medium",
-		"platform_specific":   "medium",
-	}
-	if severity, ok := severityMap[category]; ok {
-		return severity
-	}
-	return "low"
-}
-
-// getSuggestion returns a suggestion for fixing an issue
-func (s *Scanner) getSuggestion(category, matchedText string) string {
-	suggestions := map[string]string{
-		"inline_assembly":     "Replace with portable C/C++ code or use ARM NEON intrinsics",
-		"x86_intrinsics":     "Replace with ARM NEON equivalents or portable alternatives",
-		"architecture_checks": "Add ARM architecture checks or use runtime detection",
-		"platform_specific":   "Use cross-platform alternatives or add ARM-specific implementations",
-	}
-	if suggestion, ok := suggestions[category]; ok {
-		return suggestion
-	}
-	return "Review for ARM compatibility"
+	return s.annotateAffectedTargets(filePath, issues), nil
 }
 
 // scanBuildSystems scans for build system configurations
@@ -279,57 +661,14 @@ func (s *Scanner) scanBuildSystems() ([]sdk.BuildSystem, error) {
 
 			return nil
 		})
+		if err != nil {
+			continue
+		}
 	}
 
 	return buildSystems, nil
 }
 
-// scanDependencies scans project dependencies
-func (s *Scanner) scanDependencies() ([]sdk.Dependency, error) {
-	var dependencies []sdk.Dependency
-
-	// Check package.json (Node.js)
-	if deps, err := s.scanNpmDependencies(); err == nil {
-		dependencies = append(dependencies, deps...)
-	}
-
-	// Check requirements.txt (Python)
-	if deps, err := s.scanPythonDependencies(); err == nil {
-		dependencies = append(dependencies, deps...)
-	}
-
-	// Check Cargo.toml (Rust)
-	if deps, err := s.scanCargoDependencies(); err == nil {
-		dependencies = append(dependencies, deps...)
-	}
-
-	// Check go.mod (Go)
-	if deps, err := s.scanGoDependencies(); err == nil {
-		dependencies = append(dependencies, deps...)
-	}
-
-	return dependencies, nil
-}
-
-// scanNpmDependencies scans npm dependencies from package.json
-func (s *Scanner) scanNpmDependencies() ([]sdk.Dependency, error) {
-	packageJsonPath := filepath.Join(s.projectPath, "package.json")
-	if _, err := os.Stat(packageJsonPath); os.IsNotExist(err) {
-		return nil, err
-	}
-
-	// For simplicity, we'll just note that package.json exists
-	// In a real implementation, you'd parse the JSON
-	return []sdk.Dependency{
-		{
-			Name:          "npm-dependencies",
-			Version:       "detected",
-			Type:          "npm",
-			ARMCompatible: "unknown",
-		},
-	}, nil
-}
-
 // scanPythonDependencies scans Python dependencies from requirements.txt
 func (s *Scanner) scanPythonDependencies() ([]sdk.Dependency, error) {
 	requirementsPath := filepath.Join(s.projectPath, "requirements.txt")
@@ -378,70 +717,6 @@ func (s *Scanner) scanPythonDependencies() ([]sdk.Dependency, error) {
 	return dependencies, scanner.Err()
 }
 
-// scanCargoDependencies scans Rust dependencies from Cargo.toml
-func (s *Scanner) scanCargoDependencies() ([]sdk.Dependency, error) {
-	cargoTomlPath := filepath.Join(s.projectPath, "Cargo.toml")
-	if _, err := os.Stat(cargoTomlPath); os.IsNotExist(err) {
-		return nil, err
-	}
-
-	// For simplicity, we'll just note that Cargo.toml exists
-	// In a real implementation, you'd parse the TOML
-	return []sdk.Dependency{
-		{
-			Name:          "cargo-dependencies",
-			Version:       "detected",
-			Type:          "cargo",
-			ARMCompatible: "unknown",
-		},
-	}, nil
-}
-
-// scanGoDependencies scans Go dependencies from go.mod
-func (s *Scanner) scanGoDependencies() ([]sdk.Dependency, error) {
-	goModPath := filepath.Join(s.projectPath, "go.mod")
-	file, err := os.Open(goModPath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var dependencies []sdk.Dependency
-	scanner := bufio.NewScanner(file)
-	inRequireBlock := false
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		
-		if strings.HasPrefix(line, "require (") {
-			inRequireBlock = true
-			continue
-		}
-		
-		if inRequireBlock && line == ")" {
-			inRequireBlock = false
-			continue
-		}
-
-		if inRequireBlock && line != "" && !strings.HasPrefix(line, "//") {
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				name := parts[0]
-				version := parts[1]
-
-				dependencies = append(dependencies, sdk.Dependency{
-					Name:          name,
-					Version:       version,
-					Type:          "go",
-					ARMCompatible: "unknown",
-				})
-			}
-		}
-	}
-
-	return dependencies, scanner.Err()
-}
-
 // generateRecommendations generates recommendations based on scan results
 func (s *Scanner) generateRecommendations(results *sdk.ScanResults) []string {
 	var recommendations []string
@@ -458,7 +733,7 @@ func (s *Scanner) generateRecommendations(results *sdk.ScanResults) []string {
 		recommendations = append(recommendations, "âœ… No obvious x86-specific code detected")
 	} else {
 		recommendations = append(recommendations, fmt.Sprintf("ðŸ” Found %d potential compatibility issues", issueCount))
-		
+
 		if highSeverityCount > 0 {
 			recommendations = append(recommendations, fmt.Sprintf("âš ï¸  %d high-severity issues require immediate attention", highSeverityCount))
 		}
@@ -0,0 +1,289 @@
+package scanner
+
+import (
+	"bufio"
+	"go/build"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// annotateAffectedTargets sets AffectedTargets on each issue found in
+// filePath, based on which of s.targets actually reach that line once
+// build tags / #ifdef guards / CMake if() blocks are taken into account,
+// and drops issues that no declared target reaches at all (e.g. an _mm_
+// intrinsic entirely inside #ifdef __x86_64__ when every declared target is
+// arm64). It is a no-op when no targets were declared via WithTargets.
+func (s *Scanner) annotateAffectedTargets(filePath string, issues []sdk.Issue) []sdk.Issue {
+	if len(issues) == 0 || len(s.targets) == 0 {
+		return issues
+	}
+
+	ext := strings.ToLower(filepath.Ext(filePath))
+	switch {
+	case ext == ".go":
+		return annotateGoTargets(filePath, issues, s.targets)
+	case filepath.Base(filePath) == "CMakeLists.txt" || ext == ".cmake":
+		return annotateGuardedTargets(filePath, issues, s.targets, cmakeGuardOp)
+	case languageForExt(ext) == "c" || languageForExt(ext) == "cpp":
+		return annotateGuardedTargets(filePath, issues, s.targets, cGuardOp)
+	default:
+		return issues
+	}
+}
+
+// annotateGoTargets evaluates each target's go/build.Context against the
+// file's build tags and filename suffix (_arm64.go, _linux_amd64.go, ...). A
+// go:build constraint gates the whole file, so every issue in it shares the
+// same affected-targets list; if no declared target matches, the file isn't
+// built for any of them and its issues are dropped entirely.
+func annotateGoTargets(filePath string, issues []sdk.Issue, targets []BuildTarget) []sdk.Issue {
+	dir := filepath.Dir(filePath)
+	base := filepath.Base(filePath)
+
+	var affected []string
+	for _, t := range targets {
+		ctx := build.Context{GOOS: t.GOOS, GOARCH: t.GOARCH, CgoEnabled: t.CgoEnabled, Compiler: "gc"}
+		if match, err := ctx.MatchFile(dir, base); err == nil && match {
+			affected = append(affected, t.String())
+		}
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+
+	for i := range issues {
+		issues[i].AffectedTargets = affected
+	}
+	return issues
+}
+
+// guardClassifier turns one trimmed source line into a guardOp, evaluating
+// any architecture condition it contains against targets. cGuardOp and
+// cmakeGuardOp are the two implementations.
+type guardClassifier func(line string, targets []BuildTarget) guardOp
+
+// guardFrame is one level of nested #ifdef/#if/if() in the stack
+// annotateGuardedTargets walks. excluded holds the targets this frame's
+// condition rules out for its current branch. known is false for a
+// condition the classifier couldn't reason about (an unrelated macro or
+// variable); such frames exclude nothing and #else never flips them.
+type guardFrame struct {
+	excluded map[string]bool
+	known    bool
+}
+
+// flip returns the frame for the matching #else/else() branch: every
+// declared target not excluded by the original condition becomes excluded,
+// and vice versa.
+func (f guardFrame) flip(targets []BuildTarget) guardFrame {
+	flipped := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		if !f.excluded[t.String()] {
+			flipped[t.String()] = true
+		}
+	}
+	return guardFrame{excluded: flipped, known: true}
+}
+
+// guardOp is what a single source line does to the guard stack, as decided
+// by a guardClassifier.
+type guardOp struct {
+	push    bool // #if/#ifdef/#ifndef or if(...): push frame onto the stack
+	replace bool // #elif or elseif(...): replace the top frame in place
+	isElse  bool // #else or else(): flip the top frame, if known
+	isEndif bool // #endif or endif(...): pop the top frame
+	frame   guardFrame
+}
+
+// annotateGuardedTargets reads filePath line by line, tracks the active
+// #ifdef/if() guards with classify, and sets AffectedTargets on each issue
+// to the declared targets not excluded by the guards active at its line,
+// dropping any issue no declared target reaches at all.
+func annotateGuardedTargets(filePath string, issues []sdk.Issue, targets []BuildTarget, classify guardClassifier) []sdk.Issue {
+	exclusions, err := lineGuardExclusions(filePath, targets, classify)
+	if err != nil {
+		return issues
+	}
+
+	reachable := issues[:0]
+	for _, issue := range issues {
+		excluded := exclusions[issue.Line]
+		var affected []string
+		for _, t := range targets {
+			if !excluded[t.String()] {
+				affected = append(affected, t.String())
+			}
+		}
+		if len(affected) == 0 {
+			continue
+		}
+		issue.AffectedTargets = affected
+		reachable = append(reachable, issue)
+	}
+	return reachable
+}
+
+// lineGuardExclusions walks path's lines, maintaining a stack of guardFrames
+// via classify, and returns the set of declared targets excluded at each
+// line by the guards active there.
+func lineGuardExclusions(path string, targets []BuildTarget, classify guardClassifier) (map[int]map[string]bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	exclusions := make(map[int]map[string]bool)
+	var stack []guardFrame
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		op := classify(strings.TrimSpace(scanner.Text()), targets)
+
+		switch {
+		case op.push:
+			stack = append(stack, op.frame)
+		case op.replace:
+			if n := len(stack); n > 0 {
+				stack[n-1] = op.frame
+			} else {
+				stack = append(stack, op.frame)
+			}
+		case op.isElse:
+			if n := len(stack); n > 0 && stack[n-1].known {
+				stack[n-1] = stack[n-1].flip(targets)
+			}
+		case op.isEndif:
+			if n := len(stack); n > 0 {
+				stack = stack[:n-1]
+			}
+		}
+
+		exclusions[lineNum] = unionExcluded(stack)
+	}
+
+	return exclusions, scanner.Err()
+}
+
+// unionExcluded merges the excluded sets of every frame on the stack, since
+// a line nested several guards deep is reachable only under all of them.
+func unionExcluded(stack []guardFrame) map[string]bool {
+	if len(stack) == 0 {
+		return nil
+	}
+	union := make(map[string]bool)
+	for _, f := range stack {
+		for target := range f.excluded {
+			union[target] = true
+		}
+	}
+	return union
+}
+
+// archFrame builds the guardFrame for a condition that is true exactly when
+// the declared target's GOARCH equals arch (or, if negate, when it doesn't).
+// An empty arch means the condition named something other than a recognized
+// architecture, so the frame is left unknown and excludes nothing.
+func archFrame(arch string, negate bool, targets []BuildTarget) guardFrame {
+	if arch == "" {
+		return guardFrame{}
+	}
+
+	excluded := make(map[string]bool)
+	for _, t := range targets {
+		conditionTrue := (t.GOARCH == arch) != negate
+		if !conditionTrue {
+			excluded[t.String()] = true
+		}
+	}
+	return guardFrame{excluded: excluded, known: true}
+}
+
+// archOp builds the guardOp for an #if-like line given whether it is an
+// #elif-style continuation (isElif) and the architecture its condition
+// names (possibly "" if unrecognized).
+func archOp(isElif bool, arch string, negate bool, targets []BuildTarget) guardOp {
+	frame := archFrame(arch, negate, targets)
+	if isElif {
+		return guardOp{replace: true, frame: frame}
+	}
+	return guardOp{push: true, frame: frame}
+}
+
+// unknownOp is the guardOp for an #if/if() condition the classifier can't
+// reason about: it still needs to push or replace a stack frame to keep
+// #else/#endif balanced, but that frame excludes nothing.
+func unknownOp(isElif bool) guardOp {
+	if isElif {
+		return guardOp{replace: true}
+	}
+	return guardOp{push: true}
+}
+
+var (
+	reCIfdef     = regexp.MustCompile(`^#\s*(el)?ifdef\s+(\w+)`)
+	reCIfndef    = regexp.MustCompile(`^#\s*(el)?ifndef\s+(\w+)`)
+	reCIfDefined = regexp.MustCompile(`^#\s*(el)?if\s+(!)?\s*defined\s*\(?\s*(\w+)\s*\)?`)
+	reCIfGeneric = regexp.MustCompile(`^#\s*(el)?if\b`)
+	reCElse      = regexp.MustCompile(`^#\s*else\b`)
+	reCEndif     = regexp.MustCompile(`^#\s*endif\b`)
+)
+
+// cGuardOp classifies a (trimmed) C/C++ source line as a guardOp, evaluating
+// #ifdef/#ifndef/#if defined() conditions against knownCArchMacros.
+func cGuardOp(line string, targets []BuildTarget) guardOp {
+	switch {
+	case reCIfdef.MatchString(line):
+		m := reCIfdef.FindStringSubmatch(line)
+		return archOp(m[1] == "el", knownCArchMacros[m[2]], false, targets)
+	case reCIfndef.MatchString(line):
+		m := reCIfndef.FindStringSubmatch(line)
+		return archOp(m[1] == "el", knownCArchMacros[m[2]], true, targets)
+	case reCIfDefined.MatchString(line):
+		m := reCIfDefined.FindStringSubmatch(line)
+		return archOp(m[1] == "el", knownCArchMacros[m[3]], m[2] == "!", targets)
+	case reCIfGeneric.MatchString(line):
+		m := reCIfGeneric.FindStringSubmatch(line)
+		return unknownOp(m[1] == "el")
+	case reCElse.MatchString(line):
+		return guardOp{isElse: true}
+	case reCEndif.MatchString(line):
+		return guardOp{isEndif: true}
+	default:
+		return guardOp{}
+	}
+}
+
+var (
+	reCMakeProcessorIf = regexp.MustCompile(`(?i)^(else)?if\s*\(\s*(not\s+)?cmake_system_processor\s+(?:strequal|matches)\s+"([^"]+)"\s*\)`)
+	reCMakeIfGeneric   = regexp.MustCompile(`(?i)^(else)?if\s*\(`)
+	reCMakeElse        = regexp.MustCompile(`(?i)^else\s*\(`)
+	reCMakeEndif       = regexp.MustCompile(`(?i)^endif\s*\(`)
+)
+
+// cmakeGuardOp classifies a (trimmed) CMakeLists.txt line as a guardOp,
+// evaluating if(CMAKE_SYSTEM_PROCESSOR STREQUAL/MATCHES "...") conditions
+// against knownCMakeProcessors.
+func cmakeGuardOp(line string, targets []BuildTarget) guardOp {
+	switch {
+	case reCMakeProcessorIf.MatchString(line):
+		m := reCMakeProcessorIf.FindStringSubmatch(line)
+		arch := knownCMakeProcessors[strings.ToLower(m[3])]
+		return archOp(strings.EqualFold(m[1], "else"), arch, m[2] != "", targets)
+	case reCMakeIfGeneric.MatchString(line):
+		m := reCMakeIfGeneric.FindStringSubmatch(line)
+		return unknownOp(strings.EqualFold(m[1], "else"))
+	case reCMakeElse.MatchString(line):
+		return guardOp{isElse: true}
+	case reCMakeEndif.MatchString(line):
+		return guardOp{isEndif: true}
+	default:
+		return guardOp{}
+	}
+}
@@ -0,0 +1,237 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m2arm/cli/internal/sdk"
+)
+
+// writeGuardFixture writes content to a file named name under a fresh
+// t.TempDir() and returns its path.
+func writeGuardFixture(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAnnotateGuardedTargets_CIfdef(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		line    int
+		targets []BuildTarget
+		want    []string
+	}{
+		{
+			name: "x86_64 ifdef excludes arm64 target",
+			source: `#ifdef __x86_64__
+_mm_add_epi32(a, b);
+#endif
+`,
+			line:    2,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    nil,
+		},
+		{
+			name: "else branch includes the arm64 target",
+			source: `#ifdef __x86_64__
+_mm_add_epi32(a, b);
+#else
+vaddq_s32(a, b);
+#endif
+`,
+			line:    4,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    []string{"linux/arm64"},
+		},
+		{
+			name: "ifndef __aarch64__ excludes arm64 target",
+			source: `#ifndef __aarch64__
+legacy_path();
+#endif
+`,
+			line:    2,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    nil,
+		},
+		{
+			name: "if defined() excludes arm64 target",
+			source: `#if defined(__x86_64__)
+_mm_add_epi32(a, b);
+#endif
+`,
+			line:    2,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    nil,
+		},
+		{
+			name: "if !defined() includes arm64 target",
+			source: `#if !defined(__x86_64__)
+vaddq_s32(a, b);
+#endif
+`,
+			line:    2,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    []string{"linux/arm64"},
+		},
+		{
+			name: "elif arm branch includes only the matching target",
+			source: `#ifdef __x86_64__
+_mm_add_epi32(a, b);
+#elif defined(__aarch64__)
+vaddq_s32(a, b);
+#endif
+`,
+			line:    4,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "amd64"}, {GOOS: "linux", GOARCH: "arm64"}},
+			want:    []string{"linux/arm64"},
+		},
+		{
+			name: "unknown condition excludes nothing",
+			source: `#ifdef SOME_UNRELATED_FLAG
+_mm_add_epi32(a, b);
+#endif
+`,
+			line:    2,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    []string{"linux/arm64"},
+		},
+		{
+			name: "else of an unknown condition still excludes nothing",
+			source: `#ifdef SOME_UNRELATED_FLAG
+a();
+#else
+_mm_add_epi32(a, b);
+#endif
+`,
+			line:    4,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    []string{"linux/arm64"},
+		},
+		{
+			name: "nested guards intersect: outer x86_64 wins even though inner is unknown",
+			source: `#ifdef __x86_64__
+#ifdef SOME_UNRELATED_FLAG
+_mm_add_epi32(a, b);
+#endif
+#endif
+`,
+			line:    3,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeGuardFixture(t, "guard.c", tt.source)
+			issues := []sdk.Issue{{Line: tt.line}}
+
+			got := annotateGuardedTargets(path, issues, tt.targets, cGuardOp)
+
+			assertAffectedTargets(t, got, tt.want)
+		})
+	}
+}
+
+func TestAnnotateGuardedTargets_CMake(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		line    int
+		targets []BuildTarget
+		want    []string
+	}{
+		{
+			name: "STREQUAL x86_64 excludes arm64 target",
+			source: `if(CMAKE_SYSTEM_PROCESSOR STREQUAL "x86_64")
+  target_sources(app PRIVATE sse.c)
+endif()
+`,
+			line:    2,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    nil,
+		},
+		{
+			name: "else branch includes the arm64 target",
+			source: `if(CMAKE_SYSTEM_PROCESSOR STREQUAL "x86_64")
+  target_sources(app PRIVATE sse.c)
+else()
+  target_sources(app PRIVATE neon.c)
+endif()
+`,
+			line:    4,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    []string{"linux/arm64"},
+		},
+		{
+			name: "MATCHES arm excludes amd64 target",
+			source: `if(CMAKE_SYSTEM_PROCESSOR MATCHES "arm")
+  target_sources(app PRIVATE neon.c)
+endif()
+`,
+			line:    2,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "amd64"}},
+			want:    nil,
+		},
+		{
+			name: "NOT STREQUAL includes the named target",
+			source: `if(NOT CMAKE_SYSTEM_PROCESSOR STREQUAL "x86_64")
+  target_sources(app PRIVATE neon.c)
+endif()
+`,
+			line:    2,
+			targets: []BuildTarget{{GOOS: "linux", GOARCH: "arm64"}},
+			want:    []string{"linux/arm64"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeGuardFixture(t, "CMakeLists.txt", tt.source)
+			issues := []sdk.Issue{{Line: tt.line}}
+
+			got := annotateGuardedTargets(path, issues, tt.targets, cmakeGuardOp)
+
+			assertAffectedTargets(t, got, tt.want)
+		})
+	}
+}
+
+// assertAffectedTargets checks that annotateGuardedTargets either dropped
+// the sole issue passed to it (want == nil) or kept it with AffectedTargets
+// equal to want.
+func assertAffectedTargets(t *testing.T, got []sdk.Issue, want []string) {
+	t.Helper()
+	if want == nil {
+		if len(got) != 0 {
+			t.Fatalf("expected issue to be dropped as unreachable, got %+v", got)
+		}
+		return
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected exactly one issue to survive, got %+v", got)
+	}
+	if !equalStringSlices(got[0].AffectedTargets, want) {
+		t.Errorf("AffectedTargets = %v, want %v", got[0].AffectedTargets, want)
+	}
+}
+
+// equalStringSlices compares two string slices element by element, treating
+// nil and empty as equal since both have length zero.
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
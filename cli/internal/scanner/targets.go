@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildTarget is a GOOS/GOARCH/CgoEnabled tuple, mirroring the build-context
+// matrix Go's own cmd/api walks. Declaring a set of BuildTargets via
+// WithTargets lets Scanner evaluate each Issue's go:build tags, #ifdef
+// guards, and CMake if(CMAKE_SYSTEM_PROCESSOR ...) blocks against every
+// target, so e.g. an _mm_ intrinsic guarded by #ifdef __x86_64__ isn't
+// flagged when the declared targets are all arm64.
+type BuildTarget struct {
+	GOOS       string
+	GOARCH     string
+	CgoEnabled bool
+}
+
+// String renders the target the way `go build` prints it, e.g. "linux/arm64".
+func (t BuildTarget) String() string {
+	return fmt.Sprintf("%s/%s", t.GOOS, t.GOARCH)
+}
+
+// ParseBuildTarget parses a "GOOS/GOARCH" string such as "linux/arm64" into a
+// BuildTarget, the layout 'm2arm scan --targets' uses.
+func ParseBuildTarget(s string) (BuildTarget, error) {
+	goos, goarch, ok := strings.Cut(s, "/")
+	if !ok || goos == "" || goarch == "" {
+		return BuildTarget{}, fmt.Errorf("invalid build target %q: want GOOS/GOARCH", s)
+	}
+	return BuildTarget{GOOS: goos, GOARCH: goarch}, nil
+}
+
+// knownCArchMacros maps the predefined macro a compiler sets for an
+// architecture to the matching GOARCH value, so #ifdef/#if defined() guards
+// can be evaluated against a BuildTarget's GOARCH.
+var knownCArchMacros = map[string]string{
+	"__aarch64__": "arm64",
+	"__arm__":     "arm",
+	"__x86_64__":  "amd64",
+	"__amd64__":   "amd64",
+	"__i386__":    "386",
+}
+
+// knownCMakeProcessors maps the CMAKE_SYSTEM_PROCESSOR value CMake reports
+// for an architecture to the matching GOARCH value, so
+// if(CMAKE_SYSTEM_PROCESSOR STREQUAL ...) guards can be evaluated the same
+// way as C/C++ #ifdef guards.
+var knownCMakeProcessors = map[string]string{
+	"aarch64": "arm64",
+	"arm64":   "arm64",
+	"arm":     "arm",
+	"x86_64":  "amd64",
+	"amd64":   "amd64",
+	"i686":    "386",
+	"i386":    "386",
+}
@@ -0,0 +1,7 @@
+package sdk
+
+// Reporter serializes ScanResults into a specific output format, e.g. for
+// handing off to a CI system or IDE that doesn't speak m2arm's native JSON.
+type Reporter interface {
+	Report(results *ScanResults) ([]byte, error)
+}
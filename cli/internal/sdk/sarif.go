@@ -0,0 +1,261 @@
+package sdk
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// sarifSchemaURI and sarifVersion identify the SARIF spec version
+// SARIFReporter emits, per https://docs.oasis-open.org/sarif/sarif/v2.1.0.
+const (
+	sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion   = "2.1.0"
+)
+
+// SARIFReporter renders ScanResults as a SARIF 2.1.0 log, the format GitHub
+// code scanning, VS Code's Problems panel, and most other static-analysis
+// consumers expect.
+type SARIFReporter struct {
+	// ToolName and ToolVersion identify the driver in the emitted log.
+	// Both default ("m2arm" and "0.1.0", matching the Version newRootCmd
+	// sets in cmd/root.go) when
+	// left zero.
+	ToolName    string
+	ToolVersion string
+}
+
+// NewSARIFReporter returns a SARIFReporter using m2arm's own name and
+// version as the SARIF tool driver.
+func NewSARIFReporter() *SARIFReporter {
+	return &SARIFReporter{ToolName: "m2arm", ToolVersion: "0.1.0"}
+}
+
+// sarifLog, sarifRun, etc. mirror the subset of the SARIF 2.1.0 object model
+// SARIFReporter populates. Field order follows the spec's own examples.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+// categoryDescriptions gives each Issue.Category the short, human-readable
+// description SARIF consumers show next to a ruleId, since scanner rule
+// packs only carry a per-pattern Suggestion, not a per-category title.
+var categoryDescriptions = map[string]string{
+	"inline_assembly":     "Inline assembly",
+	"x86_intrinsics":      "x86 SIMD intrinsics",
+	"architecture_checks": "Architecture-specific #ifdef/build-tag check",
+	"platform_specific":   "Platform-specific API call",
+}
+
+// IntrinsicReplacements maps x86 SIMD intrinsics to their NEON equivalent,
+// the only category where a fix can be generated without understanding
+// surrounding code. It is shared by SARIFReporter's fixes and the planner's
+// CodeChange.Replacement/Confidence; suggestions for every other category
+// are prose advice, not a literal substitution.
+var IntrinsicReplacements = map[string]string{
+	"_mm_add_ps":    "vaddq_f32",
+	"_mm_sub_ps":    "vsubq_f32",
+	"_mm_mul_ps":    "vmulq_f32",
+	"_mm_div_ps":    "vdivq_f32",
+	"_mm_load_ps":   "vld1q_f32",
+	"_mm_store_ps":  "vst1q_f32",
+	"_mm_set1_ps":   "vdupq_n_f32",
+	"_mm_add_epi32": "vaddq_s32",
+	"_mm_sub_epi32": "vsubq_s32",
+	"_mm_mul_epi32": "vmulq_s32",
+}
+
+// sarifLevel maps an Issue's Severity to the SARIF result levels GitHub code
+// scanning and VS Code understand, defaulting unrecognized severities to
+// "warning" rather than dropping them.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "high":
+		return "error"
+	case "medium":
+		return "warning"
+	case "low":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// Report implements Reporter by encoding results as an indented SARIF
+// 2.1.0 JSON log.
+func (r *SARIFReporter) Report(results *ScanResults) ([]byte, error) {
+	toolName, toolVersion := r.ToolName, r.ToolVersion
+	if toolName == "" {
+		toolName = "m2arm"
+	}
+	if toolVersion == "" {
+		toolVersion = "0.1.0"
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchemaURI,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:    toolName,
+						Version: toolVersion,
+						Rules:   sarifRules(results.Issues),
+					},
+				},
+				Results: sarifResults(results.Issues),
+			},
+		},
+	}
+
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// sarifRules builds one rule per distinct Issue.Category, sorted by ID so
+// repeated runs over the same results produce byte-identical output.
+func sarifRules(issues []Issue) []sarifRule {
+	seen := make(map[string]bool)
+	var categories []string
+	for _, issue := range issues {
+		if !seen[issue.Category] {
+			seen[issue.Category] = true
+			categories = append(categories, issue.Category)
+		}
+	}
+	sort.Strings(categories)
+
+	rules := make([]sarifRule, 0, len(categories))
+	for _, category := range categories {
+		description := categoryDescriptions[category]
+		if description == "" {
+			description = category
+		}
+		rules = append(rules, sarifRule{
+			ID:               category,
+			ShortDescription: sarifMessage{Text: description},
+		})
+	}
+	return rules
+}
+
+// sarifResults converts every Issue into a SARIF result in scan order.
+func sarifResults(issues []Issue) []sarifResult {
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		results = append(results, sarifResult{
+			RuleID:  issue.Category,
+			Level:   sarifLevel(issue.Severity),
+			Message: sarifMessage{Text: issue.Suggestion},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: filepathToURI(issue.File)},
+						Region:           sarifRegion{StartLine: issue.Line},
+					},
+				},
+			},
+			Fixes: sarifFixes(issue),
+		})
+	}
+	return results
+}
+
+// sarifFixes returns a single-replacement fix when issue.MatchedText names a
+// known x86 intrinsic with a direct NEON equivalent, or nil when no
+// deterministic replacement is known.
+func sarifFixes(issue Issue) []sarifFix {
+	replacement, ok := IntrinsicReplacements[issue.MatchedText]
+	if !ok {
+		return nil
+	}
+
+	return []sarifFix{
+		{
+			Description: sarifMessage{Text: "Replace with the NEON equivalent"},
+			ArtifactChanges: []sarifArtifactChange{
+				{
+					ArtifactLocation: sarifArtifactLocation{URI: filepathToURI(issue.File)},
+					Replacements: []sarifReplacement{
+						{
+							DeletedRegion:   sarifRegion{StartLine: issue.Line},
+							InsertedContent: sarifMessage{Text: replacement},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// filepathToURI normalizes a scanned file path to the forward-slashed,
+// relative form SARIF's artifactLocation.uri expects.
+func filepathToURI(path string) string {
+	return strings.ReplaceAll(path, "\\", "/")
+}
@@ -0,0 +1,132 @@
+package sdk
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sampleSARIFResults is the fixture both TestSARIFReporter_Report_GoldenFile
+// and TestSARIFReporter_Report_RequiredFieldsPresent render, covering: a
+// high-severity issue with a known NEON replacement (so Fixes is
+// populated) and a medium-severity issue with no deterministic
+// replacement (so Fixes stays nil).
+func sampleSARIFResults() *ScanResults {
+	return &ScanResults{
+		TotalFiles:   2,
+		ScannedFiles: 2,
+		Issues: []Issue{
+			{
+				File:        "src/math_utils.c",
+				Line:        42,
+				Category:    "x86_intrinsics",
+				Pattern:     `_mm_\w+`,
+				MatchedText: "_mm_add_ps",
+				Severity:    "high",
+				Suggestion:  "Replace with ARM NEON equivalents or portable alternatives",
+			},
+			{
+				File:        "src/platform.h",
+				Line:        15,
+				Category:    "architecture_checks",
+				Pattern:     `#ifdef\s+__x86_64__`,
+				MatchedText: "#ifdef __x86_64__",
+				Severity:    "medium",
+				Suggestion:  "Add ARM architecture checks or use runtime detection",
+			},
+		},
+	}
+}
+
+func TestSARIFReporter_Report_GoldenFile(t *testing.T) {
+	got, err := (&SARIFReporter{ToolName: "m2arm", ToolVersion: "0.1.0"}).Report(sampleSARIFResults())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	golden := filepath.Join("testdata", "sample.sarif.json")
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.WriteFile(golden, append(got, '\n'), 0o644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if string(got)+"\n" != string(want) {
+		t.Errorf("Report() output does not match %s\ngot:\n%s\nwant:\n%s", golden, got, want)
+	}
+}
+
+// TestSARIFReporter_Report_RequiredFieldsPresent hand-checks the fields the
+// SARIF 2.1.0 spec (https://docs.oasis-open.org/sarif/sarif/v2.1.0)
+// requires on the subset of the object model SARIFReporter emits: the
+// log's $schema and version, each run's tool.driver.name, and each
+// result's ruleId, level, message.text, and locations[].physicalLocation.
+// This is not schema validation - it does not load or check against the
+// official SARIF JSON schema, only the handful of fields listed above.
+func TestSARIFReporter_Report_RequiredFieldsPresent(t *testing.T) {
+	data, err := NewSARIFReporter().Report(sampleSARIFResults())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var log map[string]interface{}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Report() produced invalid JSON: %v", err)
+	}
+
+	if log["$schema"] != sarifSchemaURI {
+		t.Errorf("$schema = %v, want %v", log["$schema"], sarifSchemaURI)
+	}
+	if log["version"] != sarifVersion {
+		t.Errorf("version = %v, want %v", log["version"], sarifVersion)
+	}
+
+	runs, ok := log["runs"].([]interface{})
+	if !ok || len(runs) == 0 {
+		t.Fatalf("runs = %v, want a non-empty array", log["runs"])
+	}
+
+	for _, r := range runs {
+		run := r.(map[string]interface{})
+		driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+		if name, _ := driver["name"].(string); name == "" {
+			t.Error("tool.driver.name is required and must be non-empty")
+		}
+
+		results, _ := run["results"].([]interface{})
+		for _, res := range results {
+			result := res.(map[string]interface{})
+			if ruleID, _ := result["ruleId"].(string); ruleID == "" {
+				t.Error("result.ruleId is required and must be non-empty")
+			}
+			if level, _ := result["level"].(string); level != "error" && level != "warning" && level != "note" {
+				t.Errorf("result.level = %v, want one of error/warning/note", result["level"])
+			}
+
+			message, ok := result["message"].(map[string]interface{})
+			if !ok || message["text"] == "" {
+				t.Error("result.message.text is required and must be non-empty")
+			}
+
+			locations, _ := result["locations"].([]interface{})
+			if len(locations) == 0 {
+				t.Fatal("result.locations must be non-empty")
+			}
+			physical := locations[0].(map[string]interface{})["physicalLocation"].(map[string]interface{})
+			artifact := physical["artifactLocation"].(map[string]interface{})
+			if uri, _ := artifact["uri"].(string); uri == "" {
+				t.Error("result.locations[].physicalLocation.artifactLocation.uri is required and must be non-empty")
+			}
+			region := physical["region"].(map[string]interface{})
+			if _, ok := region["startLine"].(float64); !ok {
+				t.Error("result.locations[].physicalLocation.region.startLine is required")
+			}
+		}
+	}
+}
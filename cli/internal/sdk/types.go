@@ -1,6 +1,9 @@
 package sdk
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // ScanResults represents the results of a code scan
 type ScanResults struct {
@@ -22,6 +25,13 @@ type Issue struct {
 	MatchedText string `json:"matched_text"`
 	Severity    string `json:"severity"`
 	Suggestion  string `json:"suggestion"`
+
+	// AffectedTargets lists which of the scanner's declared build-context
+	// targets (e.g. "linux/arm64") actually reach this line once #ifdef /
+	// go:build guards are taken into account. It is only populated when the
+	// scan declared at least one target; otherwise reachability is unknown
+	// and this is left nil.
+	AffectedTargets []string `json:"affected_targets,omitempty"`
 }
 
 // Dependency represents a project dependency
@@ -30,6 +40,13 @@ type Dependency struct {
 	Version       string `json:"version"`
 	Type          string `json:"type"`
 	ARMCompatible string `json:"arm_compatible"`
+
+	// Notes explains how ARMCompatible was determined, e.g. which wheel
+	// tags, npm cpu/os fields, or crates.io native-library links a
+	// Resolver found. Before any lookup it holds only what the manifest
+	// parser noted (e.g. an optional or target-gated dependency), and may
+	// be empty.
+	Notes []string `json:"notes,omitempty"`
 }
 
 // BuildSystem represents a detected build system
@@ -49,6 +66,12 @@ type MigrationPlan struct {
 	DependencyUpdates   []DependencyUpdate     `json:"dependency_updates"`
 	TestingStrategy     TestingStrategy        `json:"testing_strategy"`
 	EstimatedEffort     string                 `json:"estimated_effort"`
+
+	// Variants is one VariantPlan per requested ARMTarget, so callers can
+	// see which of Steps' CodeChanges are variant-conditional (e.g. a NEON
+	// intrinsic rewrite doesn't apply to a softfp target) without
+	// re-deriving that themselves.
+	Variants []VariantPlan `json:"variants,omitempty"`
 }
 
 // MigrationStep represents a single migration step
@@ -67,6 +90,55 @@ type CodeChange struct {
 	Original    string `json:"original"`
 	Replacement string `json:"replacement"`
 	Confidence  string `json:"confidence"`
+
+	// RequiresFeature names the ISA feature Replacement depends on (e.g.
+	// "neon"), empty if Replacement applies to every ARM variant. A
+	// VariantPlan only carries a change forward into its ApplicableSteps
+	// when its ARMTarget has the named feature.
+	RequiresFeature string `json:"requires_feature,omitempty"`
+}
+
+// ARMTarget describes one concrete ARM build target: the OS it runs on,
+// its architecture ("arm" or "arm64"), the GOARM/march-level sub-variant
+// (5/6/7 for arm, 8/8.2/... for arm64), and an optional ISA feature
+// suffix ("softfp", "vfp", "vfpv3", "vfpv4", "neon" for arm; "sve", "sve2",
+// "crypto" for arm64). See internal/armtarget for the string syntax this
+// parses from, e.g. "linux/arm/7+neon" or "arm64/v8.2+sve".
+type ARMTarget struct {
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	Variant string `json:"variant"`
+	FPU     string `json:"fpu,omitempty"`
+}
+
+// HasNEON reports whether code built for t can use NEON/ASIMD SIMD
+// intrinsics: always true for arm64, since NEON is mandatory in AArch64,
+// and true for arm only when FPU is explicitly "neon".
+func (t ARMTarget) HasNEON() bool {
+	return t.Arch == "arm64" || t.FPU == "neon"
+}
+
+// String renders t back into the "os/arch/variant[+fpu]" form
+// internal/armtarget.Parse accepts.
+func (t ARMTarget) String() string {
+	s := fmt.Sprintf("%s/%s/%s", t.OS, t.Arch, t.Variant)
+	if t.FPU != "" {
+		s += "+" + t.FPU
+	}
+	return s
+}
+
+// VariantPlan is one ARMTarget's view of a MigrationPlan: the concrete
+// toolchain flags it builds with, and the subset of Steps whose
+// CodeChanges actually apply to it once RequiresFeature is taken into
+// account.
+type VariantPlan struct {
+	Target               ARMTarget       `json:"target"`
+	GOARM                string          `json:"goarm,omitempty"`
+	MFPU                 string          `json:"mfpu,omitempty"`
+	MArch                string          `json:"march,omitempty"`
+	CMakeSystemProcessor string          `json:"cmake_system_processor"`
+	ApplicableSteps      []MigrationStep `json:"applicable_steps"`
 }
 
 // BuildSystemChange represents changes needed in build systems